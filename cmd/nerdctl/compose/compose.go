@@ -17,8 +17,14 @@
 package compose
 
 import (
+	"context"
+	"io"
+
 	"github.com/spf13/cobra"
 
+	containerd "github.com/containerd/containerd/v2/client"
+
+	"github.com/containerd/nerdctl/v2/cmd/nerdctl/container"
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
 	"github.com/containerd/nerdctl/v2/pkg/composer"
 )
@@ -64,6 +70,13 @@ func Command() *cobra.Command {
 		unpauseCommand(),
 		topCommand(),
 		createCommand(),
+		watchCommand(),
+		eventsCommand(),
+		lsCommand(),
+		scaleCommand(),
+		statsCommand(),
+		waitCommand(),
+		attachCommand(),
 	)
 
 	return cmd
@@ -95,6 +108,14 @@ func getComposeOptions(cmd *cobra.Command, debugFull, experimental bool) (compos
 	if err != nil {
 		return composer.Options{}, err
 	}
+	globalOptions, err := helpers.ProcessRootCmdFlags(cmd)
+	if err != nil {
+		return composer.Options{}, err
+	}
+	// the containers are created in-process, with the same flags as `nerdctl create`
+	createContainer := func(ctx context.Context, client *containerd.Client, args []string) (containerd.Container, error) {
+		return container.CreateFromArgs(ctx, client, globalOptions, nerdctlCmd, nerdctlArgs, args, io.Discard, cmd.ErrOrStderr())
+	}
 
 	return composer.Options{
 		Project:          projectName,
@@ -107,5 +128,6 @@ func getComposeOptions(cmd *cobra.Command, debugFull, experimental bool) (compos
 		DebugPrintFull:   debugFull,
 		Experimental:     experimental,
 		IPFSAddress:      ipfsAddressStr,
+		CreateContainer:  createContainer,
 	}, nil
 }
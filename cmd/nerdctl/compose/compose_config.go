@@ -42,6 +42,13 @@ func configCommand() *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("hash", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"\"*\""}, cobra.ShellCompDirectiveNoFileComp
 	})
+	cmd.Flags().String("format", "yaml", "Format the output. Supported values: [yaml|json]")
+	cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().Bool("resolve-image-digests", false, "Pin image tags to digests.")
+	cmd.Flags().Bool("no-interpolate", false, "Don't interpolate environment variables.")
+	cmd.Flags().StringP("output", "o", "", "Save to file (default to stdout)")
 	return cmd
 }
 
@@ -70,6 +77,25 @@ func configAction(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("unsupported format %s, supported formats are: [yaml|json]", format)
+	}
+	resolveImageDigests, err := cmd.Flags().GetBool("resolve-image-digests")
+	if err != nil {
+		return err
+	}
+	noInterpolate, err := cmd.Flags().GetBool("no-interpolate")
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
 
 	client, ctx, cancel, err := clientutil.NewClient(cmd.Context(), globalOptions.Namespace, globalOptions.Address)
 	if err != nil {
@@ -80,6 +106,7 @@ func configAction(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	options.NoInterpolate = noInterpolate
 	c, err := compose.New(client, globalOptions, options, cmd.OutOrStdout(), cmd.ErrOrStderr())
 	if err != nil {
 		return err
@@ -89,9 +116,12 @@ func configAction(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	co := composer.ConfigOptions{
-		Services: services,
-		Volumes:  volumes,
-		Hash:     hash,
+		Services:            services,
+		Volumes:             volumes,
+		Hash:                hash,
+		Format:              format,
+		ResolveImageDigests: resolveImageDigests,
+		Output:              output,
 	}
 	return c.Config(ctx, cmd.OutOrStdout(), co)
 }
@@ -17,7 +17,9 @@
 package compose
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -72,6 +74,66 @@ services:
 			},
 			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains("hello")),
 		},
+		{
+			Description: "config --format json",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYaml"), "config", "--format", "json")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains(`"hello": {`, fmt.Sprintf(`"image": %q`, testutil.CommonImage))),
+		},
+		{
+			Description: "config --resolve-image-digests",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYaml"), "config", "--resolve-image-digests")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains(testutil.CommonImage+"@sha256:")),
+		},
+		{
+			Description: "config --output",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYaml"), "config", "--output", data.Temp().Path("output.yaml"))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						assert.Equal(t, stdout, "")
+						assert.Assert(t, strings.Contains(data.Temp().Load("output.yaml"), "hello:"))
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
+func TestComposeConfigNoInterpolate(t *testing.T) {
+	const dockerComposeYAML = `
+services:
+  hello:
+    image: alpine:${TAG:-3.13}
+`
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "interpolated",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Temp().Path("compose.yaml"), "config")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains("alpine:3.13")),
+		},
+		{
+			Description: "not interpolated",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Temp().Path("compose.yaml"), "config", "--no-interpolate")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains("alpine:${TAG:-3.13}")),
+		},
 	}
 
 	testCase.Run(t)
@@ -290,3 +352,86 @@ image: hello-world
 
 	testCase.Run(t)
 }
+
+func TestComposeConfigInclude(t *testing.T) {
+	const dockerComposeYAML = `
+include:
+- path: ./team/compose.yaml
+  env_file: ./team/team.env
+services:
+  app:
+    image: alpine:3.13
+    extends:
+      file: ./base/compose.yaml
+      service: base
+`
+	const teamComposeYAML = `
+services:
+  db:
+    image: alpine:${DB_TAG}
+    build: ./db
+    volumes:
+    - ./data:/data
+`
+	const baseComposeYAML = `
+services:
+  base:
+    image: alpine:3.13
+    volumes:
+    - ./base-data:/data
+`
+	const conflictingComposeYAML = `
+include:
+- ./team/compose.yaml
+services:
+  db:
+    image: alpine:3.13
+`
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+		data.Temp().Save(conflictingComposeYAML, "conflicting.yaml")
+		data.Temp().Save(teamComposeYAML, "team", "compose.yaml")
+		data.Temp().Save("DB_TAG=3.14\n", "team", "team.env")
+		data.Temp().Save("FROM alpine:3.14\n", "team", "db", "Dockerfile")
+		data.Temp().Save(baseComposeYAML, "base", "compose.yaml")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "paths of the included project are relative to its project directory",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Temp().Path("compose.yaml"), "config")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Contains(
+						"alpine:3.14",
+						"context: "+data.Temp().Path("team", "db"),
+						"source: "+data.Temp().Path("team", "data"),
+						"source: "+data.Temp().Path("base", "base-data"),
+					),
+				}
+			},
+		},
+		{
+			Description: "conflicting services",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Temp().Path("conflicting.yaml"), "config")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Errors: []error{
+						errors.New("services.db is defined in both"),
+						errors.New(data.Temp().Path("conflicting.yaml")),
+						errors.New(data.Temp().Path("team", "compose.yaml")),
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
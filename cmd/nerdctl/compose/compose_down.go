@@ -36,6 +36,8 @@ func downCommand() *cobra.Command {
 	}
 	cmd.Flags().BoolP("volumes", "v", false, "Remove named volumes declared in the `volumes` section of the Compose file and anonymous volumes attached to containers.")
 	cmd.Flags().Bool("remove-orphans", false, "Remove containers for services not defined in the Compose file.")
+	cmd.Flags().String("rmi", "", "Remove images used by services. \"local\" remove only images that don't have a custom tag (\"local\"|\"all\")")
+	cmd.Flags().UintP("timeout", "t", 10, "Seconds to wait for stop before killing them")
 	return cmd
 }
 
@@ -57,6 +59,18 @@ func downAction(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	removeImages, err := cmd.Flags().GetString("rmi")
+	if err != nil {
+		return err
+	}
+	var timeout *uint
+	if cmd.Flags().Changed("timeout") {
+		timeValue, err := cmd.Flags().GetUint("timeout")
+		if err != nil {
+			return err
+		}
+		timeout = &timeValue
+	}
 	defer cancel()
 	options, err := getComposeOptions(cmd, globalOptions.DebugFull, globalOptions.Experimental)
 	if err != nil {
@@ -70,6 +84,8 @@ func downAction(cmd *cobra.Command, args []string) error {
 	downOpts := composer.DownOptions{
 		RemoveVolumes: volumes,
 		RemoveOrphans: removeOrphans,
+		RemoveImages:  removeImages,
+		Timeout:       timeout,
 	}
 	return c.Down(ctx, downOpts)
 }
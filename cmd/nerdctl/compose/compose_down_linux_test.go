@@ -21,8 +21,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+
 	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
 )
 
 func TestComposeDownRemoveUsedNetwork(t *testing.T) {
@@ -94,3 +98,85 @@ services:
 	base.ComposeCmd("-p", projectName, "-f", compOrphan.YAMLFullPath(), "down", "--remove-orphans").AssertOK()
 	base.ComposeCmd("-p", projectName, "-f", compFull.YAMLFullPath(), "ps", "-a").AssertOutNotContains(orphanContainer)
 }
+
+func TestComposeDownRemoveImages(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = nerdtest.Build
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		projectName := data.Identifier("project")
+		// the image of the "local" service has no custom tag, so it is named after the project
+		localImage := serviceparser.DefaultImageName(projectName, "local")
+		taggedImage := data.Identifier("tagged")
+
+		dockerComposeYAML := fmt.Sprintf(`
+services:
+  local:
+    build: .
+    command: "sleep infinity"
+  tagged:
+    build: .
+    image: %s
+    command: "sleep infinity"
+`, taggedImage)
+
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+		data.Temp().Save("FROM "+testutil.CommonImage, "Dockerfile")
+
+		data.Labels().Set("composeYaml", data.Temp().Path("compose.yaml"))
+		data.Labels().Set("projectName", projectName)
+		data.Labels().Set("localImage", localImage)
+		data.Labels().Set("taggedImage", taggedImage)
+
+		helpers.Ensure("compose", "-f", data.Labels().Get("composeYaml"), "-p", projectName, "up", "-d")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "down --rmi local removes only the images without a custom tag",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("composeYaml"), "-p", data.Labels().Get("projectName"), "down", "-t", "0", "--rmi", "local")
+			},
+			Command: test.Command("images"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.All(
+						expect.DoesNotContain(data.Labels().Get("localImage")),
+						expect.Contains(data.Labels().Get("taggedImage")),
+					),
+				}
+			},
+		},
+		{
+			Description: "down --rmi all removes all the images",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("composeYaml"), "-p", data.Labels().Get("projectName"), "down", "--rmi", "all")
+			},
+			Command: test.Command("images"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.DoesNotContain(data.Labels().Get("taggedImage")),
+				}
+			},
+		},
+		{
+			Description: "down --rmi with an invalid value fails",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYaml"), "-p", data.Labels().Get("projectName"), "down", "--rmi", "bogus")
+			},
+			Expected: test.Expects(1, nil, nil),
+		},
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		if data.Labels().Get("composeYaml") != "" {
+			helpers.Anyhow("compose", "-f", data.Labels().Get("composeYaml"), "-p", data.Labels().Get("projectName"), "down", "-v")
+			helpers.Anyhow("rmi", "-f", data.Labels().Get("localImage"), data.Labels().Get("taggedImage"))
+		}
+	}
+
+	testCase.Run(t)
+}
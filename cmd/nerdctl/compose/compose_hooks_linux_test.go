@@ -0,0 +1,64 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+)
+
+func TestComposeLifecycleHooks(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	hostDir := t.TempDir()
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %s
+    command: "sleep infinity"
+    volumes:
+    - %s:/hooks
+    post_start:
+    - command: sh -c "echo $${GREETING} > /tmp/post-start"
+      environment:
+        GREETING: hello
+    pre_stop:
+    - command: touch /hooks/pre-stop
+`, testutil.CommonImage, hostDir)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+
+	containerName := serviceparser.DefaultContainerName(projectName, "svc0", "1")
+	base.Cmd("exec", containerName, "cat", "/tmp/post-start").AssertOutContains("hello")
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "stop").AssertOK()
+	_, err := os.Stat(filepath.Join(hostDir, "pre-stop"))
+	assert.NilError(t, err)
+}
@@ -35,7 +35,9 @@ func logsCommand() *cobra.Command {
 	}
 	cmd.Flags().BoolP("follow", "f", false, "Follow log output.")
 	cmd.Flags().BoolP("timestamps", "t", false, "Show timestamps")
-	cmd.Flags().String("tail", "all", "Number of lines to show from the end of the logs")
+	cmd.Flags().String("tail", "all", "Number of lines to show from the end of the logs for each service")
+	cmd.Flags().String("since", "", "Show logs since timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().String("until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
 	cmd.Flags().Bool("no-color", false, "Produce monochrome output")
 	cmd.Flags().Bool("no-log-prefix", false, "Don't print prefix in logs")
 	return cmd
@@ -58,6 +60,14 @@ func logsAction(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return err
+	}
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return err
+	}
 	noColor, err := cmd.Flags().GetBool("no-color")
 	if err != nil {
 		return err
@@ -85,6 +95,8 @@ func logsAction(cmd *cobra.Command, args []string) error {
 		Follow:      follow,
 		Timestamps:  timestamps,
 		Tail:        tail,
+		Since:       since,
+		Until:       until,
 		NoColor:     noColor,
 		NoLogPrefix: noLogPrefix,
 	}
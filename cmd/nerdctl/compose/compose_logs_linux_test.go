@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+)
+
+func TestComposeLogsMerged(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %[1]s
+    command: sh -c "echo log-first; sleep 2; echo log-third; sleep infinity"
+  svc1:
+    image: %[1]s
+    command: sh -c "sleep 1; echo log-second; sleep infinity"
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+	time.Sleep(4 * time.Second)
+
+	inOrder := func(strs ...string) func(stdout string) error {
+		return func(stdout string) error {
+			idx := -1
+			for _, s := range strs {
+				i := strings.Index(stdout, s)
+				if i < 0 {
+					return fmt.Errorf("expected %q in the output %q", s, stdout)
+				}
+				if i < idx {
+					return fmt.Errorf("expected %v in order, got %q", strs, stdout)
+				}
+				idx = i
+			}
+			return nil
+		}
+	}
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "logs").AssertOutWithFunc(inOrder("log-first", "log-second", "log-third"))
+	// the tail is applied to each service
+	tailCmd := base.ComposeCmd("-f", comp.YAMLFullPath(), "logs", "--tail", "1")
+	tailCmd.AssertOutWithFunc(inOrder("log-second", "log-third"))
+	tailCmd.AssertOutNotContains("log-first")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "logs", "--until", "2000-01-01T00:00:00Z").AssertOutNotContains("log-")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "logs", "--since", "1h").AssertOutContainsAll("log-first", "log-second", "log-third")
+}
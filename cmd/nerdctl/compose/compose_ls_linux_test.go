@@ -0,0 +1,108 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
+)
+
+func TestComposeLs(t *testing.T) {
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %[1]s
+    command: "sleep infinity"
+  svc1:
+    image: %[1]s
+    command: "true"
+`, testutil.CommonImage)
+
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+		data.Labels().Set("yamlPath", data.Temp().Path("compose.yaml"))
+		data.Labels().Set("projectName", data.Identifier())
+		helpers.Ensure("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "up", "-d")
+		// wait for svc1 to exit
+		time.Sleep(3 * time.Second)
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "down")
+	}
+
+	// the subtests are run sequentially, as they change the state of the project
+	testCase.SubTests = []*test.Case{
+		{
+			NoParallel:  true,
+			Description: "table",
+			Command:     test.Command("compose", "ls"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Contains(data.Labels().Get("projectName"), "exited(1), running(1)", data.Labels().Get("yamlPath")),
+				}
+			},
+		},
+		{
+			NoParallel:  true,
+			Description: "json",
+			Command:     test.Command("compose", "ls", "--format", "json"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Contains(fmt.Sprintf(`{"Name":%q,"Status":"exited(1), running(1)"`, data.Labels().Get("projectName"))),
+				}
+			},
+		},
+		{
+			NoParallel:  true,
+			Description: "not running",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "stop")
+			},
+			Command: test.Command("compose", "ls"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.DoesNotContain(data.Labels().Get("projectName")),
+				}
+			},
+		},
+		{
+			NoParallel:  true,
+			Description: "all",
+			Command:     test.Command("compose", "ls", "--all"),
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Contains(data.Labels().Get("projectName"), "exited(2)"),
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/cmd/compose"
 	"github.com/containerd/nerdctl/v2/pkg/containerutil"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 	"github.com/containerd/nerdctl/v2/pkg/portutil"
 )
@@ -65,7 +66,7 @@ type composeContainerPrintable struct {
 	Project  string
 	Service  string
 	State    string
-	Health   string // placeholder, lack containerd support.
+	Health   string
 	ExitCode uint32
 	// `Publishers` stores docker-compatible ports and used for json output.
 	// `Ports` stores formatted ports and only used for console output.
@@ -246,6 +247,9 @@ func composeContainerPrintableTab(ctx context.Context, container containerd.Cont
 	status := formatter.ContainerStatus(ctx, container)
 	if status == "Up" {
 		status = "running" // corresponds to Docker Compose v2.0.1
+		if health := containerHealth(info.Labels); health != "" {
+			status = fmt.Sprintf("%s (%s)", status, health)
+		}
 	}
 	image, err := container.Image(ctx)
 	if err != nil {
@@ -327,7 +331,7 @@ func composeContainerPrintableJSON(ctx context.Context, container containerd.Con
 		Project:    info.Labels[labels.ComposeProject],
 		Service:    info.Labels[labels.ComposeService],
 		State:      state,
-		Health:     "",
+		Health:     containerHealth(info.Labels),
 		ExitCode:   exitCode,
 		Publishers: formatPublishers(portMappings),
 	}, nil
@@ -362,6 +366,16 @@ func formatPublishers(portMappings []cni.PortMapping) []PortPublisher {
 	return dockerPorts
 }
 
+// containerHealth returns the health status recorded in the container labels,
+// or an empty string if the container has no healthcheck.
+func containerHealth(containerLabels map[string]string) string {
+	status := healthcheck.StatusFromLabels(containerLabels)
+	if status == healthcheck.NoHealthcheck {
+		return ""
+	}
+	return status
+}
+
 // statusForFilter returns the status value to be matched with the 'status' filter
 func statusForFilter(ctx context.Context, c containerd.Container) string {
 	task, err := c.Task(ctx, nil)
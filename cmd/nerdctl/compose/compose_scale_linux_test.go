@@ -0,0 +1,112 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
+)
+
+func TestComposeScale(t *testing.T) {
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %[1]s
+    command: "sleep infinity"
+  svc1:
+    image: %[1]s
+    command: "sleep infinity"
+`, testutil.CommonImage)
+
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+		data.Labels().Set("yamlPath", data.Temp().Path("compose.yaml"))
+		data.Labels().Set("projectName", data.Identifier())
+		helpers.Ensure("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "up", "-d")
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "down")
+	}
+
+	// the subtests are run sequentially, as they change the number of replicas
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "scale up",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "scale", "svc0=3")
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "ps", "--format", "json")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				projectName := data.Labels().Get("projectName")
+				return &test.Expected{
+					Output: expect.Contains(
+						serviceparser.DefaultContainerName(projectName, "svc0", "1"),
+						serviceparser.DefaultContainerName(projectName, "svc0", "2"),
+						serviceparser.DefaultContainerName(projectName, "svc0", "3"),
+						serviceparser.DefaultContainerName(projectName, "svc1", "1"),
+					),
+				}
+			},
+		},
+		{
+			Description: "scale down",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "scale", "svc0=1", "svc1=0")
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "ps", "-a", "--format", "json")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				projectName := data.Labels().Get("projectName")
+				return &test.Expected{
+					Output: expect.All(
+						expect.Contains(serviceparser.DefaultContainerName(projectName, "svc0", "1")),
+						expect.DoesNotContain(
+							serviceparser.DefaultContainerName(projectName, "svc0", "2"),
+							serviceparser.DefaultContainerName(projectName, "svc0", "3"),
+							serviceparser.DefaultContainerName(projectName, "svc1", "1"),
+						),
+					),
+				}
+			},
+		},
+		{
+			Description: "invalid argument",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("yamlPath"), "-p", data.Labels().Get("projectName"), "scale", "svc0")
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
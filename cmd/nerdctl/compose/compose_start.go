@@ -90,7 +90,7 @@ func startAction(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("service %q has no container to start", svcName)
 		}
 
-		if err := startContainers(ctx, client, containers, &globalOptions, nerdctlCmd, nerdctlArgs); err != nil {
+		if err := startContainers(ctx, client, containers, &globalOptions, nerdctlCmd, nerdctlArgs, c.RunPostStartHooks); err != nil {
 			return err
 		}
 	}
@@ -98,7 +98,8 @@ func startAction(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func startContainers(ctx context.Context, client *containerd.Client, containers []containerd.Container, globalOptions *types.GlobalCommandOptions, nerdctlCmd string, nerdctlArgs []string) error {
+func startContainers(ctx context.Context, client *containerd.Client, containers []containerd.Container, globalOptions *types.GlobalCommandOptions, nerdctlCmd string, nerdctlArgs []string,
+	postStart func(context.Context, containerd.Container) error) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, c := range containers {
 		c := c
@@ -119,6 +120,9 @@ func startContainers(ctx context.Context, client *containerd.Client, containers
 			if err := containerutil.Start(ctx, c, false, false, client, "", "", (*config.Config)(globalOptions), nerdctlCmd, nerdctlArgs); err != nil {
 				return err
 			}
+			if err := postStart(ctx, c); err != nil {
+				return err
+			}
 			info, err := c.Info(ctx, containerd.WithoutRefreshedMetadata)
 			if err != nil {
 				return err
@@ -51,6 +51,12 @@ func upCommand() *cobra.Command {
 	cmd.Flags().Bool("no-recreate", false, "Don't recreate containers if they exist, conflict with --force-recreate.")
 	cmd.Flags().StringArray("scale", []string{}, "Scale SERVICE to NUM instances. Overrides the `scale` setting in the Compose file if present.")
 	cmd.Flags().String("pull", "", "Pull image before running (\"always\"|\"missing\"|\"never\")")
+	cmd.Flags().String("exit-code-from", "", "Return the exit code of the selected service container. Implies --abort-on-container-exit")
+	cmd.Flags().Bool("attach-dependencies", false, "Attach to the log output of the dependent services too")
+	cmd.Flags().Bool("no-deps", false, "Don't start linked services")
+	cmd.Flags().Bool("no-start", false, "Don't start the services after creating them")
+	cmd.Flags().BoolP("renew-anon-volumes", "V", false, "Recreate anonymous volumes instead of retrieving data from the previous containers")
+	cmd.Flags().Duration("dependency-timeout", composer.DefaultDependencyTimeout, "Maximum duration to wait for a dependency to satisfy its depends_on condition")
 	return cmd
 }
 
@@ -70,6 +76,42 @@ func upAction(cmd *cobra.Command, services []string) error {
 	if err != nil {
 		return err
 	}
+	exitCodeFrom, err := cmd.Flags().GetString("exit-code-from")
+	if err != nil {
+		return err
+	}
+	if exitCodeFrom != "" {
+		if detach {
+			return errors.New("--exit-code-from flag is incompatible with flag --detach")
+		}
+		abortOnContainerExit = true
+	}
+	attachDependencies, err := cmd.Flags().GetBool("attach-dependencies")
+	if err != nil {
+		return err
+	}
+	if detach && attachDependencies {
+		return errors.New("--attach-dependencies flag is incompatible with flag --detach")
+	}
+	noDeps, err := cmd.Flags().GetBool("no-deps")
+	if err != nil {
+		return err
+	}
+	noStart, err := cmd.Flags().GetBool("no-start")
+	if err != nil {
+		return err
+	}
+	if noStart && abortOnContainerExit {
+		return errors.New("--no-start flag is incompatible with flags --abort-on-container-exit and --exit-code-from")
+	}
+	renewAnonVolumes, err := cmd.Flags().GetBool("renew-anon-volumes")
+	if err != nil {
+		return err
+	}
+	dependencyTimeout, err := cmd.Flags().GetDuration("dependency-timeout")
+	if err != nil {
+		return err
+	}
 	noBuild, err := cmd.Flags().GetBool("no-build")
 	if err != nil {
 		return err
@@ -162,6 +204,12 @@ func upAction(cmd *cobra.Command, services []string) error {
 		Pull:                 pull,
 		ForceRecreate:        forceRecreate,
 		NoRecreate:           noRecreate,
+		ExitCodeFrom:         exitCodeFrom,
+		AttachDependencies:   attachDependencies,
+		NoDeps:               noDeps,
+		NoStart:              noStart,
+		RenewAnonVolumes:     renewAnonVolumes,
+		DependencyTimeout:    dependencyTimeout,
 	}
 	return c.Up(ctx, uo, services)
 }
@@ -671,3 +671,196 @@ services:
 	base.Cmd("images").AssertOutNotContains(testutil.CommonImage)
 	base.ComposeCmd("-f", comp.YAMLFullPath(), "up").AssertExitCode(1)
 }
+
+func TestComposeUpDependsOnCompletedSuccessfully(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  init:
+    image: %[1]s
+    command: sh -euxc "sleep 3; echo init-done"
+  app:
+    image: %[1]s
+    command: sleep infinity
+    depends_on:
+      init:
+        condition: service_completed_successfully
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "logs", "init").AssertOutContains("init-done")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "ps", "app").AssertOutContains("running")
+}
+
+func TestComposeUpDependsOnCompletedSuccessfullyFailure(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  init:
+    image: %[1]s
+    command: sh -euxc "exit 3"
+  app:
+    image: %[1]s
+    command: sleep infinity
+    depends_on:
+      init:
+        condition: service_completed_successfully
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertFail()
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "ps", "-a", "app").AssertOutNotContains(serviceparser.DefaultContainerName(projectName, "app", "1"))
+}
+
+func TestComposeUpExitCodeFrom(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  db:
+    image: %[1]s
+    command: sh -euxc "echo db-started && sleep infinity"
+  app:
+    image: %[1]s
+    command: sh -euxc "echo app-started && sleep 3 && exit 42"
+    depends_on:
+      - db
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+	res := base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "--exit-code-from", "app", "app").Run()
+	assert.Equal(t, 42, res.ExitCode, res.Combined())
+	// the logs of the dependency are not attached without --attach-dependencies
+	assert.Assert(t, strings.Contains(res.Stdout(), "app-started"), res.Combined())
+	assert.Assert(t, !strings.Contains(res.Stdout(), "db-started"), res.Combined())
+
+	res = base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "--exit-code-from", "app", "--attach-dependencies", "app").Run()
+	assert.Equal(t, 42, res.ExitCode, res.Combined())
+	assert.Assert(t, strings.Contains(res.Stdout(), "db-started"), res.Combined())
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "--exit-code-from", "no-such-service").AssertFail()
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d", "--exit-code-from", "app").AssertFail()
+}
+
+func TestComposeUpNoDepsNoStart(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  db:
+    image: %[1]s
+    command: "sleep infinity"
+  app:
+    image: %[1]s
+    command: "sleep infinity"
+    depends_on:
+      - db
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+
+	dbName := serviceparser.DefaultContainerName(projectName, "db", "1")
+	appName := serviceparser.DefaultContainerName(projectName, "app", "1")
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d", "--no-deps", "app").AssertOK()
+	base.Cmd("container", "inspect", dbName).AssertFail()
+	base.Cmd("container", "inspect", appName, "--format", "{{.State.Status}}").AssertOutContains("running")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").AssertOK()
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "--no-start").AssertOK()
+	base.Cmd("container", "inspect", dbName, "--format", "{{.State.Status}}").AssertOutContains("created")
+	base.Cmd("container", "inspect", appName, "--format", "{{.State.Status}}").AssertOutContains("created")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "--no-start", "--abort-on-container-exit").AssertFail()
+}
+
+func TestComposeUpRenewAnonVolumes(t *testing.T) {
+	base := testutil.NewBase(t)
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %s
+    command: "sleep infinity"
+    volumes:
+    - /data
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+
+	containerName := serviceparser.DefaultContainerName(projectName, "svc0", "1")
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+	base.Cmd("exec", containerName, "sh", "-c", "echo hello > /data/file").AssertOK()
+
+	// the anonymous volume of the previous container is reused by default
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d", "--force-recreate").AssertOK()
+	base.Cmd("exec", containerName, "cat", "/data/file").AssertOutExactly("hello\n")
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d", "--force-recreate", "--renew-anon-volumes").AssertOK()
+	base.Cmd("exec", containerName, "ls", "/data/file").AssertFail()
+}
+
+func TestComposeUpInlineConfigsAndSecrets(t *testing.T) {
+	base := testutil.NewBase(t)
+	base.Env = append(base.Env, "COMPOSE_TEST_SECRET=secret-from-environment")
+
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  svc0:
+    image: %s
+    command: "sleep infinity"
+    configs:
+      - source: inline_config
+        target: /etc/inline_config
+      - source: empty_config
+        target: /etc/empty_config
+    secrets:
+      - env_secret
+configs:
+  inline_config:
+    content: "config-from-content"
+  empty_config:
+    content: ""
+secrets:
+  env_secret:
+    environment: COMPOSE_TEST_SECRET
+`, testutil.CommonImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+	projectName := comp.ProjectName()
+	t.Logf("projectName=%q", projectName)
+
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+
+	containerName := serviceparser.DefaultContainerName(projectName, "svc0", "1")
+	base.Cmd("exec", containerName, "cat", "/etc/inline_config").AssertOutExactly("config-from-content")
+	base.Cmd("exec", containerName, "cat", "/etc/empty_config").AssertOutExactly("")
+	base.Cmd("exec", containerName, "cat", "/run/secrets/env_secret").AssertOutExactly("secret-from-environment")
+}
@@ -17,12 +17,16 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"runtime"
 
 	"github.com/spf13/cobra"
 	cdiparser "tags.cncf.io/container-device-interface/pkg/parser"
 
+	containerd "github.com/containerd/containerd/v2/client"
+
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/clientutil"
@@ -56,20 +60,28 @@ func CreateCommand() *cobra.Command {
 	return cmd
 }
 
-//revive:disable:function-length
 func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
-	var err error
-	opt := types.ContainerCreateOptions{
-		Stdout: cmd.OutOrStdout(),
-		Stderr: cmd.ErrOrStderr(),
-	}
-
-	opt.GOptions, err = helpers.ProcessRootCmdFlags(cmd)
+	globalOptions, err := helpers.ProcessRootCmdFlags(cmd)
 	if err != nil {
-		return opt, err
+		return types.ContainerCreateOptions{}, err
 	}
+	nerdctlCmd, nerdctlArgs := helpers.GlobalFlags(cmd)
+	return createOptionsWithGlobalOptions(cmd, globalOptions, nerdctlCmd, nerdctlArgs)
+}
 
-	opt.NerdctlCmd, opt.NerdctlArgs = helpers.GlobalFlags(cmd)
+// createOptionsWithGlobalOptions parses the flags of `cmd` without relying on the flags of the root command,
+// so that it can be used by CreateFromArgs.
+//
+//revive:disable:function-length
+func createOptionsWithGlobalOptions(cmd *cobra.Command, globalOptions types.GlobalCommandOptions, nerdctlCmd string, nerdctlArgs []string) (types.ContainerCreateOptions, error) {
+	var err error
+	opt := types.ContainerCreateOptions{
+		Stdout:      cmd.OutOrStdout(),
+		Stderr:      cmd.ErrOrStderr(),
+		GOptions:    globalOptions,
+		NerdctlCmd:  nerdctlCmd,
+		NerdctlArgs: nerdctlArgs,
+	}
 
 	// #region for basic flags
 	// The command `container start` doesn't support the flag `--interactive`. Set the default value of `opt.Interactive` false.
@@ -279,10 +291,22 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.HealthStartInterval, err = cmd.Flags().GetDuration("health-start-interval")
+	if err != nil {
+		return opt, err
+	}
+	opt.HealthStartupCmd, err = cmd.Flags().GetString("health-startup-cmd")
+	if err != nil {
+		return opt, err
+	}
 	opt.NoHealthcheck, err = cmd.Flags().GetBool("no-healthcheck")
 	if err != nil {
 		return opt, err
 	}
+	opt.HealthOnFailure, err = cmd.Flags().GetString("health-on-failure")
+	if err != nil {
+		return opt, err
+	}
 	if err := helpers.ValidateHealthcheckFlags(opt); err != nil {
 		return opt, err
 	}
@@ -562,3 +586,41 @@ func createAction(cmd *cobra.Command, args []string) error {
 	fmt.Fprintln(createOpt.Stdout, c.ID())
 	return nil
 }
+
+// CreateFromArgs creates a container from the arguments of `nerdctl create`,
+// e.g., []string{"--name=foo", "--pull=never", "alpine", "sleep", "infinity"}, without re-executing nerdctl.
+// nerdctlCmd and nerdctlArgs are used for running the healthchecks of the container.
+func CreateFromArgs(ctx context.Context, client *containerd.Client, globalOptions types.GlobalCommandOptions, nerdctlCmd string, nerdctlArgs []string, args []string, stdout, stderr io.Writer) (containerd.Container, error) {
+	cmd := CreateCommand()
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	if err := cmd.ParseFlags(args); err != nil {
+		return nil, err
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		return nil, err
+	}
+	createOpt, err := createOptionsWithGlobalOptions(cmd, globalOptions, nerdctlCmd, nerdctlArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	netFlags, err := loadNetworkFlags(cmd, createOpt.GOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load networking flags: %w", err)
+	}
+
+	netManager, err := containerutil.NewNetworkingOptionsManager(createOpt.GOptions, netFlags, client)
+	if err != nil {
+		return nil, err
+	}
+
+	c, gc, err := container.Create(ctx, client, cmd.Flags().Args(), netManager, createOpt)
+	if err != nil {
+		if gc != nil {
+			gc()
+		}
+		return nil, err
+	}
+	return c, nil
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -1155,3 +1157,376 @@ func TestHealthCheck_SystemdIntegration_Advanced(t *testing.T) {
 	}
 	testCase.Run(t)
 }
+
+func TestHealthCheck_Scheduler(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	// the healthchecks are run by the scheduler process when systemd timers are disabled
+	tomlPath := filepath.Join(t.TempDir(), "nerdctl.toml")
+	assert.NilError(t, os.WriteFile(tomlPath, []byte("disable_hc_systemd = true\n"), 0o400))
+	testCase.Env = map[string]string{"NERDCTL_TOML": tomlPath}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "Scheduler runs the healthcheck periodically",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "echo healthy",
+					"--health-interval", "1s",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("inspect", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						var h *healthcheck.Health
+						for i := 0; i < 10; i++ {
+							h = nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+							if h != nil && len(h.Log) >= 2 {
+								break
+							}
+							time.Sleep(1 * time.Second)
+						}
+						assert.Assert(t, h != nil, "expected health state to be present")
+						assert.Equal(t, h.Status, healthcheck.Healthy)
+						assert.Assert(t, len(h.Log) >= 2, "expected the healthcheck to run more than once")
+					}),
+				}
+			},
+		},
+		{
+			Description: "Stop tears down the scheduler",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "echo healthy",
+					"--health-interval", "1s",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				time.Sleep(2 * time.Second)
+				helpers.Ensure("stop", data.Identifier())
+				h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+				assert.Assert(helpers.T(), h != nil, "expected health state to be present")
+				data.Labels().Set("logs", fmt.Sprint(len(h.Log)))
+				time.Sleep(3 * time.Second)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("inspect", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+						assert.Assert(t, h != nil, "expected health state to be present")
+						assert.Equal(t, fmt.Sprint(len(h.Log)), data.Labels().Get("logs"), "expected no healthcheck to run after stop")
+					}),
+				}
+			},
+		},
+	}
+	testCase.Run(t)
+}
+
+func TestHealthCheck_OnFailure(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "Invalid --health-on-failure is rejected",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("create", "--name", data.Identifier(),
+					"--health-cmd", "false",
+					"--health-on-failure", "invalid",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+		{
+			Description: "Unhealthy container is stopped with --health-on-failure=stop",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "false",
+					"--health-retries", "1",
+					"--health-on-failure", "stop",
+					"--stop-timeout", "1",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "healthcheck", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						inspect := nerdtest.InspectContainer(helpers, data.Identifier())
+						assert.Equal(t, inspect.State.Status, "exited")
+						assert.Assert(t, inspect.State.Health != nil, "expected health state to be present")
+						assert.Equal(t, inspect.State.Health.Status, healthcheck.Unhealthy)
+					}),
+				}
+			},
+		},
+		{
+			Description: "Unhealthy container is restarted with --health-on-failure=restart",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "test ! -f /tmp/unhealthy",
+					"--health-retries", "1",
+					"--health-on-failure", "restart",
+					testutil.CommonImage, "sh", "-c", "touch /tmp/unhealthy; sleep "+nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				pid := nerdtest.InspectContainer(helpers, data.Identifier()).State.Pid
+				data.Labels().Set("pid", fmt.Sprint(pid))
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "healthcheck", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						inspect := nerdtest.InspectContainer(helpers, data.Identifier())
+						assert.Equal(t, inspect.State.Status, "running")
+						assert.Assert(t, fmt.Sprint(inspect.State.Pid) != data.Labels().Get("pid"), "expected the container to be restarted")
+						assert.Equal(t, inspect.RestartCount, 1)
+						assert.Assert(t, inspect.State.Health != nil, "expected health state to be present")
+						assert.Equal(t, inspect.State.Health.Status, healthcheck.Starting)
+					}),
+				}
+			},
+		},
+		{
+			Description: "Unhealthy container stopped with --health-on-failure=stop is not restarted by --restart=always",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--restart", "always",
+					"--health-cmd", "false",
+					"--health-retries", "1",
+					"--health-on-failure", "stop",
+					"--stop-timeout", "1",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "healthcheck", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						inspect := nerdtest.InspectContainer(helpers, data.Identifier())
+						assert.Equal(t, inspect.State.Status, "exited")
+						assert.Equal(t, inspect.State.Restarting, false)
+						assert.Equal(t, inspect.RestartCount, 0)
+					}),
+				}
+			},
+		},
+		{
+			Description: "Unhealthy container with --restart=always is restarted by the restart monitor with --health-on-failure=restart",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--restart", "always",
+					"--health-cmd", "test ! -f /tmp/unhealthy",
+					"--health-retries", "1",
+					"--health-on-failure", "restart",
+					testutil.CommonImage, "sh", "-c", "touch /tmp/unhealthy; sleep "+nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				pid := nerdtest.InspectContainer(helpers, data.Identifier()).State.Pid
+				data.Labels().Set("pid", fmt.Sprint(pid))
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "healthcheck", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						// the restart monitor of containerd restarts the container on its own interval
+						var inspect dockercompat.Container
+						for i := 0; i < 30; i++ {
+							inspect = nerdtest.InspectContainer(helpers, data.Identifier())
+							if inspect.State.Status == "running" && fmt.Sprint(inspect.State.Pid) != data.Labels().Get("pid") {
+								break
+							}
+							time.Sleep(1 * time.Second)
+						}
+						assert.Equal(t, inspect.State.Status, "running")
+						assert.Assert(t, fmt.Sprint(inspect.State.Pid) != data.Labels().Get("pid"), "expected the container to be restarted")
+						assert.Equal(t, inspect.RestartCount, 1)
+					}),
+				}
+			},
+		},
+	}
+	testCase.Run(t)
+}
+
+func TestHealthCheck_StartupCheck(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "Negative --health-start-interval is rejected",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("create", "--name", data.Identifier(),
+					"--health-cmd", "true",
+					"--health-start-interval", "-1s",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+		{
+			Description: "--health-startup-cmd without a health check is rejected",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("create", "--name", data.Identifier(),
+					"--health-startup-cmd", "true",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+		{
+			Description: "Startup check gates the health check",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-startup-cmd", "test -f /tmp/started",
+					"--health-cmd", "true",
+					"--health-retries", "1",
+					"--health-start-period", "1h",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				// the failing startup check is ignored during the start period
+				helpers.Ensure("container", "healthcheck", data.Identifier())
+				h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+				assert.Assert(helpers.T(), h != nil, "expected health state to be present")
+				assert.Equal(helpers.T(), h.Status, healthcheck.Starting)
+				// the succeeding startup check hands over to the health check
+				helpers.Ensure("exec", data.Identifier(), "touch", "/tmp/started")
+				helpers.Ensure("container", "healthcheck", data.Identifier())
+				h = nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+				assert.Equal(helpers.T(), h.Status, healthcheck.Starting)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "healthcheck", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+						assert.Assert(t, h != nil, "expected health state to be present")
+						assert.Equal(t, h.Status, healthcheck.Healthy)
+						assert.Equal(t, len(h.Log), 3)
+					}),
+				}
+			},
+		},
+		{
+			Description: "Health state is reset when the container is started again",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "true",
+					"--health-start-period", "1h",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				helpers.Ensure("container", "healthcheck", data.Identifier())
+				h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+				assert.Assert(helpers.T(), h != nil, "expected health state to be present")
+				assert.Equal(helpers.T(), h.Status, healthcheck.Healthy)
+				helpers.Ensure("stop", "-t", "1", data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("start", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						h := nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+						assert.Assert(t, h != nil, "expected health state to be present")
+						assert.Equal(t, h.Status, healthcheck.Starting)
+						assert.Equal(t, h.FailingStreak, 0)
+					}),
+				}
+			},
+		},
+		{
+			Description: "Checks run every --health-start-interval during the start period",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "false",
+					"--health-interval", "1h",
+					"--health-start-period", "1h",
+					"--health-start-interval", "1s",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("inspect", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						var h *healthcheck.Health
+						for i := 0; i < 10; i++ {
+							h = nerdtest.InspectContainer(helpers, data.Identifier()).State.Health
+							if h != nil && len(h.Log) >= 2 {
+								break
+							}
+							time.Sleep(1 * time.Second)
+						}
+						assert.Assert(t, h != nil, "expected health state to be present")
+						assert.Equal(t, h.Status, healthcheck.Starting)
+						assert.Assert(t, len(h.Log) >= 2, "expected the healthcheck to run more than once")
+					}),
+				}
+			},
+		},
+	}
+	testCase.Run(t)
+}
@@ -26,6 +26,8 @@ import (
 
 	"gotest.tools/v3/assert"
 
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
 	"github.com/containerd/nerdctl/mod/tigron/tig"
 
@@ -699,3 +701,70 @@ func TestContainerListStatusFilter(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestContainerListHealthFilter(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("run", "-d", "--name", data.Identifier("healthy"),
+			"--health-cmd", "true", testutil.CommonImage, "sleep", nerdtest.Infinity)
+		helpers.Ensure("run", "-d", "--name", data.Identifier("none"),
+			testutil.CommonImage, "sleep", nerdtest.Infinity)
+		nerdtest.EnsureContainerStarted(helpers, data.Identifier("healthy"))
+		helpers.Ensure("container", "healthcheck", data.Identifier("healthy"))
+		data.Labels().Set("healthy", data.Identifier("healthy"))
+		data.Labels().Set("none", data.Identifier("none"))
+	}
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier("healthy"), data.Identifier("none"))
+	}
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "ps filter with health=healthy",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("ps", "--filter", "health=healthy", "--format", "{{.Names}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.All(
+						expect.Contains(data.Labels().Get("healthy")),
+						expect.DoesNotContain(data.Labels().Get("none")),
+					),
+				}
+			},
+		},
+		{
+			Description: "ps filter with health=none",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("ps", "--filter", "health=none", "--format", "{{.Names}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.All(
+						expect.Contains(data.Labels().Get("none")),
+						expect.DoesNotContain(data.Labels().Get("healthy")),
+					),
+				}
+			},
+		},
+		{
+			Description: "ps filter with health=unhealthy",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("ps", "--filter", "health=unhealthy", "--format", "{{.Names}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.DoesNotContain(data.Labels().Get("healthy"), data.Labels().Get("none")),
+				}
+			},
+		},
+		{
+			Description: "ps filter with an invalid health status",
+			Command:     test.Command("ps", "--filter", "health=invalid"),
+			Expected:    test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
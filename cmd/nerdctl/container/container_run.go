@@ -242,7 +242,13 @@ func setCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().Duration("health-timeout", 0, "Maximum time to allow one check to run (default: 30s)")
 	cmd.Flags().Int("health-retries", 0, "Consecutive failures needed to report unhealthy (default: 3)")
 	cmd.Flags().Duration("health-start-period", 0, "Start period for the container to initialize before starting health-retries countdown")
+	cmd.Flags().Duration("health-start-interval", 0, "Time between running the check during the start period (default: same as --health-interval)")
+	cmd.Flags().String("health-startup-cmd", "", "Command to run in place of --health-cmd until it succeeds, to check that the container has started")
 	cmd.Flags().Bool("no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
+	cmd.Flags().String("health-on-failure", healthcheck.OnFailureNone, "Action to take when the container becomes unhealthy, one of \"none\", \"kill\", \"restart\" and \"stop\"")
+	cmd.RegisterFlagCompletionFunc("health-on-failure", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{healthcheck.OnFailureNone, healthcheck.OnFailureKill, healthcheck.OnFailureRestart, healthcheck.OnFailureStop}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// #region env flags
 	// entrypoint needs to be StringArray, not StringSlice, to prevent "FOO=foo1,foo2" from being split to {"FOO=foo1", "foo2"}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/fs"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 )
 
 func VerifyOptions(cmd *cobra.Command) (opt types.ImageVerifyOptions, err error) {
@@ -52,10 +53,11 @@ func ValidateHealthcheckFlags(options types.ContainerCreateOptions) error {
 		options.HealthInterval != 0 ||
 			options.HealthTimeout != 0 ||
 			options.HealthRetries != 0 ||
-			options.HealthStartPeriod != 0
+			options.HealthStartPeriod != 0 ||
+			options.HealthStartInterval != 0
 
 	if options.NoHealthcheck {
-		if options.HealthCmd != "" || healthFlagsSet {
+		if options.HealthCmd != "" || options.HealthStartupCmd != "" || healthFlagsSet {
 			return fmt.Errorf("--no-healthcheck conflicts with --health-* options")
 		}
 	}
@@ -73,6 +75,18 @@ func ValidateHealthcheckFlags(options types.ContainerCreateOptions) error {
 	if options.HealthStartPeriod < 0 {
 		return fmt.Errorf("--health-start-period cannot be negative")
 	}
+	if options.HealthStartInterval < 0 {
+		return fmt.Errorf("--health-start-interval cannot be negative")
+	}
+	switch options.HealthOnFailure {
+	case "", healthcheck.OnFailureNone:
+	case healthcheck.OnFailureKill, healthcheck.OnFailureRestart, healthcheck.OnFailureStop:
+		if options.NoHealthcheck {
+			return fmt.Errorf("--no-healthcheck conflicts with --health-on-failure=%s", options.HealthOnFailure)
+		}
+	default:
+		return fmt.Errorf("invalid --health-on-failure %q: must be one of \"none\", \"kill\", \"restart\" and \"stop\"", options.HealthOnFailure)
+	}
 	return nil
 }
 
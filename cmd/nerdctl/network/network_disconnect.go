@@ -0,0 +1,72 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package network
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/containerd/nerdctl/v2/cmd/nerdctl/completion"
+	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/clientutil"
+	"github.com/containerd/nerdctl/v2/pkg/cmd/network"
+)
+
+func disconnectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "disconnect [flags] NETWORK CONTAINER",
+		Short:             "Disconnect a container from a network",
+		Args:              helpers.IsExactArgs(2),
+		RunE:              disconnectAction,
+		ValidArgsFunction: networkDisconnectShellComplete,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+	}
+	return cmd
+}
+
+func disconnectAction(cmd *cobra.Command, args []string) error {
+	globalOptions, err := helpers.ProcessRootCmdFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	options := types.NetworkDisconnectOptions{
+		GOptions:  globalOptions,
+		Network:   args[0],
+		Container: args[1],
+	}
+
+	client, ctx, cancel, err := clientutil.NewClient(cmd.Context(), options.GOptions.Namespace, options.GOptions.Address)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return network.Disconnect(ctx, client, options)
+}
+
+func networkDisconnectShellComplete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completion.NetworkNames(cmd, []string{"host", "none"})
+	case 1:
+		return completion.ContainerNames(cmd, nil)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -119,3 +119,36 @@ func TestEventFilters(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestEventHealthStatus(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("run", "-d", "--name", data.Identifier(),
+			"--health-cmd", "true", testutil.CommonImage, "sleep", nerdtest.Infinity)
+		nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+	}
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		cmd := helpers.Command("events", "--filter", "event=health_status", "--format", "json")
+		cmd.WithTimeout(10 * time.Second)
+		cmd.Background()
+		// the first successful check flips the status from starting to healthy
+		helpers.Ensure("container", "healthcheck", data.Identifier())
+		return cmd
+	}
+	testCase.Expected = func(data test.Data, helpers test.Helpers) *test.Expected {
+		return &test.Expected{
+			ExitCode: expect.ExitCodeTimeout,
+			Output: expect.Contains(
+				"\"Status\":\"health_status\"",
+				"\\\"status\\\":\\\"healthy\\\"",
+			),
+		}
+	}
+
+	testCase.Run(t)
+}
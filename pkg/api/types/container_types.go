@@ -300,12 +300,15 @@ type ContainerCreateOptions struct {
 	ImagePullOpt ImagePullOptions
 
 	// Healthcheck related fields
-	HealthCmd         string
-	HealthInterval    time.Duration
-	HealthTimeout     time.Duration
-	HealthRetries     int
-	HealthStartPeriod time.Duration
-	NoHealthcheck     bool
+	HealthCmd           string
+	HealthInterval      time.Duration
+	HealthTimeout       time.Duration
+	HealthRetries       int
+	HealthStartPeriod   time.Duration
+	HealthStartInterval time.Duration
+	HealthStartupCmd    string
+	NoHealthcheck       bool
+	HealthOnFailure     string
 
 	// UserNS name for user namespace mapping of container
 	UserNS string
@@ -557,4 +560,6 @@ type ContainerStatsOptions struct {
 	NoStream bool
 	// Do not truncate output.
 	NoTrunc bool
+	// Show the compose service of the containers, for `nerdctl compose stats`.
+	ShowService bool
 }
@@ -81,3 +81,23 @@ type NetworkRemoveOptions struct {
 	// Networks are the networks to be removed
 	Networks []string
 }
+
+// NetworkConnectOptions specifies options for `nerdctl network connect`.
+type NetworkConnectOptions struct {
+	// GOptions is the global options
+	GOptions GlobalCommandOptions
+	// Network is the network to connect the container to
+	Network string
+	// Container is the container to be connected
+	Container string
+}
+
+// NetworkDisconnectOptions specifies options for `nerdctl network disconnect`.
+type NetworkDisconnectOptions struct {
+	// GOptions is the global options
+	GOptions GlobalCommandOptions
+	// Network is the network to disconnect the container from
+	Network string
+	// Container is the container to be disconnected
+	Container string
+}
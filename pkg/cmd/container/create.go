@@ -344,6 +344,23 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 	if healthcheckConfig != "" {
 		internalLabels.healthcheck = healthcheckConfig
 	}
+	if options.HealthOnFailure != healthcheck.OnFailureNone {
+		internalLabels.healthOnFailure = options.HealthOnFailure
+	}
+	if options.HealthStartupCmd != "" {
+		if healthcheckConfig == "" {
+			return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), errors.New("--health-startup-cmd requires a health check command")
+		}
+		startupTest, err := healthcheck.TestFromCommand(options.HealthStartupCmd)
+		if err != nil {
+			return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
+		}
+		startupCheck, err := json.Marshal(startupTest)
+		if err != nil {
+			return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
+		}
+		internalLabels.healthStartupCheck = string(startupCheck)
+	}
 
 	lCOpts, err := withContainerLabels(options.Label, options.LabelFile, ensuredImage)
 	if err != nil {
@@ -726,7 +743,9 @@ type internalLabels struct {
 
 	user string
 
-	healthcheck string
+	healthcheck        string
+	healthOnFailure    string
+	healthStartupCheck string
 }
 
 // WithInternalLabels sets the internal labels for a container.
@@ -847,6 +866,14 @@ func withInternalLabels(internalLabels internalLabels) (containerd.NewContainerO
 		m[labels.HealthCheck] = internalLabels.healthcheck
 	}
 
+	if internalLabels.healthOnFailure != "" {
+		m[labels.HealthOnFailure] = internalLabels.healthOnFailure
+	}
+
+	if internalLabels.healthStartupCheck != "" {
+		m[labels.HealthStartupCheck] = internalLabels.healthStartupCheck
+	}
+
 	return containerd.WithAdditionalContainerLabels(m), nil
 }
 
@@ -877,7 +904,11 @@ func withHealthcheck(options types.ContainerCreateOptions, ensuredImage *imgutil
 
 	// Apply CLI overrides
 	if options.HealthCmd != "" {
-		hc.Test = []string{"CMD-SHELL", options.HealthCmd}
+		test, err := healthcheck.TestFromCommand(options.HealthCmd)
+		if err != nil {
+			return "", err
+		}
+		hc.Test = test
 	}
 	if options.HealthInterval != 0 {
 		hc.Interval = options.HealthInterval
@@ -891,6 +922,9 @@ func withHealthcheck(options types.ContainerCreateOptions, ensuredImage *imgutil
 	if options.HealthStartPeriod != 0 {
 		hc.StartPeriod = options.HealthStartPeriod
 	}
+	if options.HealthStartInterval != 0 {
+		hc.StartInterval = options.HealthStartInterval
+	}
 
 	// Apply defaults for any unset values, but only if we have a healthcheck configured
 	if len(hc.Test) > 0 && hc.Test[0] != "NONE" {
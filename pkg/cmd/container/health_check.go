@@ -64,7 +64,7 @@ func HealthCheck(ctx context.Context, client *containerd.Client, container conta
 	}
 
 	// Execute the health check
-	return healthcheck.ExecuteHealthCheck(ctx, task, container, hcConfig)
+	return healthcheck.ExecuteHealthCheck(ctx, client, task, container, hcConfig)
 }
 
 func isContainerRunning(ctx context.Context, container containerd.Container) (containerd.Task, error) {
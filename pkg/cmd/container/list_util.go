@@ -30,6 +30,7 @@ import (
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/containerutil"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 )
 
 func foldContainerFilters(ctx context.Context, containers []containerd.Container, filters []string) (*containerFilterContext, error) {
@@ -50,6 +51,7 @@ type containerFilterContext struct {
 	labelFilterFuncs   []func(map[string]string) bool
 	volumeFilterFuncs  []func([]*containerutil.ContainerVolume) bool
 	networkFilterFuncs []func([]string) bool
+	healthFilterFuncs  []func(healthcheck.HealthStatus) bool
 
 	all bool
 }
@@ -78,7 +80,7 @@ func (cl *containerFilterContext) foldFilters(ctx context.Context, filters []str
 		{"before", cl.foldBeforeFilter}, {"since", cl.foldSinceFilter},
 		{"network", cl.foldNetworkFilter}, {"label", cl.foldLabelFilter},
 		{"volume", cl.foldVolumeFilter}, {"status", cl.foldStatusFilter},
-		{"exited", cl.foldExitedFilter},
+		{"exited", cl.foldExitedFilter}, {"health", cl.foldHealthFilter},
 	}
 	for _, filter := range filters {
 		invalidFilter := true
@@ -134,6 +136,18 @@ func (cl *containerFilterContext) foldStatusFilter(_ context.Context, filter, va
 	return nil
 }
 
+func (cl *containerFilterContext) foldHealthFilter(_ context.Context, filter, value string) error {
+	switch value {
+	case healthcheck.Starting, healthcheck.Healthy, healthcheck.Unhealthy, healthcheck.NoHealthcheck:
+		cl.healthFilterFuncs = append(cl.healthFilterFuncs, func(status healthcheck.HealthStatus) bool {
+			return status == value
+		})
+	default:
+		return fmt.Errorf("invalid filter '%s'", filter)
+	}
+	return nil
+}
+
 func (cl *containerFilterContext) foldBeforeFilter(ctx context.Context, filter, value string) error {
 	beforeC, err := idOrNameFilter(ctx, cl.containers, value)
 	if err == nil {
@@ -225,13 +239,14 @@ func (cl *containerFilterContext) foldNetworkFilter(_ context.Context, filter, v
 
 func (cl *containerFilterContext) matchesInfoFilters(ctx context.Context, container containerd.Container) bool {
 	if len(cl.idFilterFuncs)+len(cl.nameFilterFuncs)+len(cl.beforeFilterFuncs)+
-		len(cl.sinceFilterFuncs)+len(cl.labelFilterFuncs)+len(cl.volumeFilterFuncs)+len(cl.networkFilterFuncs) == 0 {
+		len(cl.sinceFilterFuncs)+len(cl.labelFilterFuncs)+len(cl.volumeFilterFuncs)+len(cl.networkFilterFuncs)+
+		len(cl.healthFilterFuncs) == 0 {
 		return true
 	}
 	info, _ := container.Info(ctx, containerd.WithoutRefreshedMetadata)
 	return cl.matchesIDFilter(info) && cl.matchesNameFilter(info) && cl.matchesBeforeFilter(info) &&
 		cl.matchesSinceFilter(info) && cl.matchesLabelFilter(info) && cl.matchesVolumeFilter(info) &&
-		cl.matchesNetworkFilter(info)
+		cl.matchesNetworkFilter(info) && cl.matchesHealthFilter(info)
 }
 
 func (cl *containerFilterContext) matchesTaskFilters(ctx context.Context, container containerd.Container) bool {
@@ -377,6 +392,20 @@ func (cl *containerFilterContext) matchesNetworkFilter(info containers.Container
 	return false
 }
 
+func (cl *containerFilterContext) matchesHealthFilter(info containers.Container) bool {
+	if len(cl.healthFilterFuncs) == 0 {
+		return true
+	}
+	status := healthcheck.StatusFromLabels(info.Labels)
+	for _, healthFilterFunc := range cl.healthFilterFuncs {
+		if !healthFilterFunc(status) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 func idOrNameFilter(ctx context.Context, containers []containerd.Container, value string) (*containers.Container, error) {
 	for _, container := range containers {
 		info, err := container.Info(ctx, containerd.WithoutRefreshedMetadata)
@@ -42,6 +42,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
 	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
 	"github.com/containerd/nerdctl/v2/pkg/infoutil"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 	"github.com/containerd/nerdctl/v2/pkg/statsutil"
 )
@@ -81,6 +82,12 @@ func (s *stats) isKnownContainer(cid string) (int, bool) {
 	return -1, false
 }
 
+func newStats(id string, clabels map[string]string) *statsutil.Stats {
+	s := statsutil.NewStats(id, containerutil.GetContainerName(clabels))
+	s.Service = clabels[labels.ComposeService]
+	return s
+}
+
 // Stats displays a live stream of container(s) resource usage statistics.
 func Stats(ctx context.Context, client *containerd.Client, containerIDs []string, options types.ContainerStatsOptions) error {
 	// NOTE: rootless container does not rely on cgroupv1.
@@ -149,7 +156,7 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 			}
 			// if an error occurs when getting labels, the ID alone is sufficient for the stats screen.
 			clabels, _ := c.Labels(ctx)
-			s := statsutil.NewStats(c.ID(), containerutil.GetContainerName(clabels))
+			s := newStats(c.ID(), clabels)
 			if cStats.add(s) {
 				waitFirst.Add(1)
 				go collect(ctx, options.GOptions, s, waitFirst, c.ID(), !options.NoStream)
@@ -184,7 +191,7 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 			// if an error occurs, the ID alone is sufficient for the stats screen.
 			container, _ := client.LoadContainer(ctx, datacc.ID)
 			clabels, _ := container.Labels(ctx)
-			s := statsutil.NewStats(datacc.ID, containerutil.GetContainerName(clabels))
+			s := newStats(datacc.ID, clabels)
 			if cStats.add(s) {
 				waitFirst.Add(1)
 				go collect(ctx, options.GOptions, s, waitFirst, datacc.ID, !options.NoStream)
@@ -234,7 +241,7 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 			OnFound: func(ctx context.Context, found containerwalker.Found) error {
 				// if an error occurs when getting labels, the ID alone is sufficient for the stats screen.
 				clabels, _ := found.Container.Labels(ctx)
-				s := statsutil.NewStats(found.Container.ID(), containerutil.GetContainerName(clabels))
+				s := newStats(found.Container.ID(), clabels)
 				if cStats.add(s) {
 					waitFirst.Add(1)
 					go collect(ctx, options.GOptions, s, waitFirst, found.Container.ID(), !options.NoStream)
@@ -280,7 +287,11 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 		if !firstTick {
 			// print header for every tick
 			if options.Format == "" || options.Format == "table" {
-				fmt.Fprintln(w, "CONTAINER ID\tNAME\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS")
+				if options.ShowService {
+					fmt.Fprintln(w, "CONTAINER ID\tSERVICE\tNAME\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS")
+				} else {
+					fmt.Fprintln(w, "CONTAINER ID\tNAME\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS")
+				}
 			}
 		}
 
@@ -298,6 +309,20 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 					if _, err = fmt.Fprintln(options.Stdout, b.String()); err != nil {
 						break
 					}
+				} else if options.ShowService {
+					if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						rc.ID,
+						rc.Service,
+						rc.Name,
+						rc.CPUPerc,
+						rc.MemUsage,
+						rc.MemPerc,
+						rc.NetIO,
+						rc.BlockIO,
+						rc.PIDs,
+					); err != nil {
+						break
+					}
 				} else {
 					if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 						rc.ID,
@@ -0,0 +1,292 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/clientutil"
+	"github.com/containerd/nerdctl/v2/pkg/dnsutil/hostsstore"
+	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
+	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/netutil"
+	"github.com/containerd/nerdctl/v2/pkg/netutil/nettype"
+	"github.com/containerd/nerdctl/v2/pkg/netutil/networkstore"
+	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
+)
+
+// Connect connects a container to a network.
+// When the container is running, the CNI plugins of the network are invoked against
+// the network namespace of its task. Otherwise, the network is attached on the next start.
+func Connect(ctx context.Context, client *containerd.Client, options types.NetworkConnectOptions) error {
+	if runtime.GOOS != "linux" {
+		return errors.New("network connect is only supported on linux")
+	}
+	e, err := netutil.NewCNIEnv(options.GOptions.CNIPath, options.GOptions.CNINetConfPath, netutil.WithNamespace(options.GOptions.Namespace), netutil.WithDefaultNetwork(options.GOptions.BridgeIP))
+	if err != nil {
+		return err
+	}
+	netw, err := e.NetworkByNameOrID(options.Network)
+	if err != nil {
+		return err
+	}
+
+	return walkContainer(ctx, client, options.Container, func(ctx context.Context, c containerd.Container) error {
+		networks, err := containerNetworks(ctx, c)
+		if err != nil {
+			return err
+		}
+		if networkIndex(e, networks, netw.Name) >= 0 {
+			return fmt.Errorf("container %s is already connected to network %s", c.ID(), netw.Name)
+		}
+		task, err := runningTask(ctx, c)
+		if err != nil {
+			return err
+		}
+		if task != nil {
+			if err := attachNetwork(ctx, c, task, e, netw, networks, options.GOptions); err != nil {
+				return err
+			}
+		}
+		connected := append(networks, netw.Name)
+		if err := updateNetworks(ctx, c, connected); err != nil {
+			// the network would be left attached to the task, without being recorded in the labels
+			if task != nil {
+				if detachErr := detachNetwork(ctx, c, task, e, netw, connected, netw.Name, options.GOptions); detachErr != nil {
+					log.G(ctx).WithError(detachErr).Warnf("failed to detach network %s from container %s", netw.Name, c.ID())
+				}
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func attachNetwork(ctx context.Context, c containerd.Container, task containerd.Task, e *netutil.CNIEnv,
+	netw *netutil.NetworkConfig, networks []string, globalOptions types.GlobalCommandOptions) error {
+	spec, err := task.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	taskNetworks, err := decodeNetworks(spec.Annotations[labels.Networks])
+	if err != nil {
+		return err
+	}
+	dataStore, err := clientutil.DataStore(globalOptions.DataRoot, globalOptions.Address)
+	if err != nil {
+		return err
+	}
+	ns, err := networkstore.New(dataStore, globalOptions.Namespace, c.ID())
+	if err != nil {
+		return err
+	}
+	if err := ns.Load(); err != nil {
+		return err
+	}
+	hs, err := hostsstore.New(dataStore, globalOptions.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ifName := nextInterfaceName(interfaceNames(taskNetworks, networks, ns.NetConf.Interfaces))
+	fullID := globalOptions.Namespace + "-" + c.ID()
+	nsPath := fmt.Sprintf("/proc/%d/ns/net", task.Pid())
+	args := [][2]string{
+		{"IgnoreUnknown", "1"},
+		{"NERDCTL_CNI_DHCP_HOSTNAME", spec.Annotations[labels.Hostname]},
+	}
+	return withCNILock(globalOptions.CNINetConfPath, func() (err error) {
+		res, err := e.AttachNetwork(ctx, netw, fullID, nsPath, ifName, args)
+		if err != nil {
+			return fmt.Errorf("failed to attach network %s: %w", netw.Name, err)
+		}
+		defer func() {
+			if err != nil {
+				_ = e.DetachNetwork(ctx, netw, fullID, nsPath, ifName)
+			}
+		}()
+
+		if ns.NetConf.Interfaces == nil {
+			ns.NetConf.Interfaces = make(map[string]string)
+		}
+		ns.NetConf.Interfaces[netw.Name] = ifName
+		if err = ns.Acquire(ns.NetConf); err != nil {
+			return err
+		}
+		return hs.UpdateNetwork(c.ID(), netw.Name, res)
+	})
+}
+
+func walkContainer(ctx context.Context, client *containerd.Client, req string, fn func(context.Context, containerd.Container) error) error {
+	walker := &containerwalker.ContainerWalker{
+		Client: client,
+		OnFound: func(ctx context.Context, found containerwalker.Found) error {
+			if found.MatchCount > 1 {
+				return fmt.Errorf("multiple IDs found with provided prefix: %s", found.Req)
+			}
+			return fn(ctx, found.Container)
+		},
+	}
+	if n, err := walker.Walk(ctx, req); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("no such container %s", req)
+	}
+	return nil
+}
+
+func decodeNetworks(networksJSON string) ([]string, error) {
+	var networks []string
+	if err := json.Unmarshal([]byte(networksJSON), &networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+// containerNetworks returns the networks of the container, as recorded in its labels.
+// Only containers using CNI networks can be connected to, or disconnected from, a network.
+func containerNetworks(ctx context.Context, c containerd.Container) ([]string, error) {
+	containerLabels, err := c.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := decodeNetworks(containerLabels[labels.Networks])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse label %q of container %s: %w", labels.Networks, c.ID(), err)
+	}
+	netType, err := nettype.Detect(networks)
+	if err != nil {
+		return nil, err
+	}
+	if netType != nettype.CNI {
+		return nil, fmt.Errorf("container %s uses network %q and cannot be connected to, or disconnected from, a network", c.ID(), networks[0])
+	}
+	return networks, nil
+}
+
+// networkIndex returns the index of the network named name in networks, or -1.
+// The entries of networks may be network names or IDs.
+func networkIndex(e *netutil.CNIEnv, networks []string, name string) int {
+	for i, n := range networks {
+		if n == name {
+			return i
+		}
+		if netw, err := e.NetworkByNameOrID(n); err == nil && netw.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// runningTask returns the task of the container if it has a network namespace
+// the CNI plugins can be invoked against, or nil otherwise.
+func runningTask(ctx context.Context, c containerd.Container) (containerd.Task, error) {
+	task, err := c.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch status.Status {
+	case containerd.Running, containerd.Paused, containerd.Pausing:
+		return task, nil
+	default:
+		return nil, nil
+	}
+}
+
+// interfaceNames returns the interface name of each network attached to a running task.
+// The networks the task was started with are named "eth<index>" by the OCI hook, while
+// the networks connected afterwards are recorded in the network store.
+func interfaceNames(taskNetworks, networks []string, connected map[string]string) map[string]string {
+	res := make(map[string]string)
+	for i, n := range taskNetworks {
+		for _, attached := range networks {
+			if n == attached {
+				res[n] = fmt.Sprintf("eth%d", i)
+			}
+		}
+	}
+	for n, ifName := range connected {
+		res[n] = ifName
+	}
+	return res
+}
+
+// nextInterfaceName returns the first "eth<N>" name that is not in use.
+func nextInterfaceName(used map[string]string) string {
+	inUse := make(map[string]struct{}, len(used))
+	for _, ifName := range used {
+		inUse[ifName] = struct{}{}
+	}
+	for i := 0; ; i++ {
+		ifName := fmt.Sprintf("eth%d", i)
+		if _, ok := inUse[ifName]; !ok {
+			return ifName
+		}
+	}
+}
+
+// updateNetworks records the networks of the container in its labels, and in the
+// annotations of its spec so that they are set up by the OCI hook on the next start.
+func updateNetworks(ctx context.Context, c containerd.Container, networks []string) error {
+	networksJSON, err := json.Marshal(networks)
+	if err != nil {
+		return err
+	}
+	spec, err := c.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	return c.Update(ctx,
+		containerd.UpdateContainerOpts(containerd.WithAdditionalContainerLabels(map[string]string{
+			labels.Networks: string(networksJSON),
+		})),
+		containerd.UpdateContainerOpts(containerd.WithSpec(spec, oci.WithAnnotations(map[string]string{
+			labels.Networks: string(networksJSON),
+		}))),
+	)
+}
+
+// withCNILock runs fn while holding the lock the OCI hook takes around CNI operations.
+func withCNILock(cniNetconfPath string, fn func() error) error {
+	return rootlessutil.WithDetachedNetNSIfAny(func() error {
+		lock, err := filesystem.Lock(filepath.Join(cniNetconfPath, ".cni-concurrency.lock"))
+		if err != nil {
+			return err
+		}
+		defer filesystem.Unlock(lock)
+		return fn()
+	})
+}
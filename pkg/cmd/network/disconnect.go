@@ -0,0 +1,121 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+
+	containerd "github.com/containerd/containerd/v2/client"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/clientutil"
+	"github.com/containerd/nerdctl/v2/pkg/dnsutil/hostsstore"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/netutil"
+	"github.com/containerd/nerdctl/v2/pkg/netutil/networkstore"
+)
+
+// Disconnect disconnects a container from a network.
+// When the container is running, the CNI plugins of the network are invoked to detach
+// it from the network namespace of its task.
+func Disconnect(ctx context.Context, client *containerd.Client, options types.NetworkDisconnectOptions) error {
+	if runtime.GOOS != "linux" {
+		return errors.New("network disconnect is only supported on linux")
+	}
+	e, err := netutil.NewCNIEnv(options.GOptions.CNIPath, options.GOptions.CNINetConfPath, netutil.WithNamespace(options.GOptions.Namespace), netutil.WithDefaultNetwork(options.GOptions.BridgeIP))
+	if err != nil {
+		return err
+	}
+	netw, err := e.NetworkByNameOrID(options.Network)
+	if err != nil {
+		return err
+	}
+
+	return walkContainer(ctx, client, options.Container, func(ctx context.Context, c containerd.Container) error {
+		networks, err := containerNetworks(ctx, c)
+		if err != nil {
+			return err
+		}
+		idx := networkIndex(e, networks, netw.Name)
+		if idx < 0 {
+			return fmt.Errorf("container %s is not connected to network %s", c.ID(), netw.Name)
+		}
+		if len(networks) == 1 {
+			return fmt.Errorf("container %s cannot be disconnected from its last network %s", c.ID(), netw.Name)
+		}
+		task, err := runningTask(ctx, c)
+		if err != nil {
+			return err
+		}
+		if task != nil {
+			if err := detachNetwork(ctx, c, task, e, netw, networks, networks[idx], options.GOptions); err != nil {
+				return err
+			}
+		}
+		return updateNetworks(ctx, c, slices.Delete(networks, idx, idx+1))
+	})
+}
+
+func detachNetwork(ctx context.Context, c containerd.Container, task containerd.Task, e *netutil.CNIEnv,
+	netw *netutil.NetworkConfig, networks []string, network string, globalOptions types.GlobalCommandOptions) error {
+	spec, err := task.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	taskNetworks, err := decodeNetworks(spec.Annotations[labels.Networks])
+	if err != nil {
+		return err
+	}
+	dataStore, err := clientutil.DataStore(globalOptions.DataRoot, globalOptions.Address)
+	if err != nil {
+		return err
+	}
+	ns, err := networkstore.New(dataStore, globalOptions.Namespace, c.ID())
+	if err != nil {
+		return err
+	}
+	if err := ns.Load(); err != nil {
+		return err
+	}
+	hs, err := hostsstore.New(dataStore, globalOptions.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ifName, ok := interfaceNames(taskNetworks, networks, ns.NetConf.Interfaces)[network]
+	if !ok {
+		return fmt.Errorf("no interface found for network %s in container %s", netw.Name, c.ID())
+	}
+	fullID := globalOptions.Namespace + "-" + c.ID()
+	nsPath := fmt.Sprintf("/proc/%d/ns/net", task.Pid())
+	return withCNILock(globalOptions.CNINetConfPath, func() error {
+		if err := e.DetachNetwork(ctx, netw, fullID, nsPath, ifName); err != nil {
+			return fmt.Errorf("failed to detach network %s: %w", netw.Name, err)
+		}
+		if _, ok := ns.NetConf.Interfaces[network]; ok {
+			delete(ns.NetConf.Interfaces, network)
+			if err := ns.Acquire(ns.NetConf); err != nil {
+				return err
+			}
+		}
+		return hs.UpdateNetwork(c.ID(), network, nil)
+	})
+}
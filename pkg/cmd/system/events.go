@@ -34,6 +34,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck" // Register health_status event type
 )
 
 // EventOut contains information about an event.
@@ -49,11 +50,12 @@ type EventOut struct {
 type Status string
 
 const (
-	START   Status = "start"
-	UNKNOWN Status = "unknown"
+	START        Status = "start"
+	HEALTHSTATUS Status = "health_status"
+	UNKNOWN      Status = "unknown"
 )
 
-var statuses = [...]Status{START, UNKNOWN}
+var statuses = [...]Status{START, HEALTHSTATUS, UNKNOWN}
 
 func isStatus(status string) bool {
 	status = strings.ToLower(status)
@@ -68,6 +70,9 @@ func isStatus(status string) bool {
 }
 
 func TopicToStatus(topic string) Status {
+	if topic == healthcheck.HealthStatusEventTopic {
+		return HEALTHSTATUS
+	}
 	if strings.Contains(strings.ToLower(topic), string(START)) {
 		return START
 	}
@@ -0,0 +1,56 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"io"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlcontainer "github.com/containerd/nerdctl/v2/pkg/cmd/container"
+)
+
+// AttachOptions stores options passed from users as flags and args.
+type AttachOptions struct {
+	ServiceName string
+	Index       int
+	DetachKeys  string
+	Stdin       io.Reader
+	Stdout      io.Writer
+	Stderr      io.Writer
+}
+
+// Attach attaches the standard streams to a running container specified by
+// `ServiceName` (and `Index` if it has multiple instances), as `nerdctl attach` does.
+func (c *Composer) Attach(ctx context.Context, ao AttachOptions) error {
+	// Attach does not need to lock and should allow concurrency.
+	if err := Unlock(); err != nil {
+		return err
+	}
+
+	container, err := c.serviceContainer(ctx, ao.ServiceName, ao.Index)
+	if err != nil {
+		return err
+	}
+	return nerdctlcontainer.Attach(ctx, c.client, container.ID(), types.ContainerAttachOptions{
+		GOptions:   c.globalOptions(),
+		Stdin:      ao.Stdin,
+		Stdout:     ao.Stdout,
+		Stderr:     ao.Stderr,
+		DetachKeys: ao.DetachKeys,
+	})
+}
@@ -32,6 +32,7 @@ type BuildOptions struct {
 	Args     []string // --build-arg strings
 	NoCache  bool
 	Progress string
+	Quiet    bool // suppress the build output
 }
 
 func (c *Composer) Build(ctx context.Context, bo BuildOptions, services []string) error {
@@ -63,6 +64,9 @@ func (c *Composer) buildServiceImage(ctx context.Context, image string, b *servi
 	if bo.Progress != "" {
 		args = append(args, "--progress="+bo.Progress)
 	}
+	if bo.Quiet {
+		args = append(args, "--quiet")
+	}
 
 	if b.DockerfileInline != "" {
 		// if DockerfileInline is specified, write it to a temporary file
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"sync"
 
 	composecli "github.com/compose-spec/compose-go/v2/cli"
 	compose "github.com/compose-spec/compose-go/v2/types"
@@ -50,16 +51,19 @@ type Options struct {
 	VolumeExists     func(string) (bool, error)
 	ImageExists      func(ctx context.Context, imageName string) (bool, error)
 	EnsureImage      func(ctx context.Context, imageName, pullMode, platform string, ps *serviceparser.Service, quiet bool) error
-	DebugPrintFull   bool // full debug print, may leak secret env var to logs
-	Experimental     bool // enable experimental features
-	IPFSAddress      string
+	// CreateContainer parses the flags of `nerdctl create` and creates the container in-process
+	CreateContainer func(ctx context.Context, client *containerd.Client, args []string) (containerd.Container, error)
+	DebugPrintFull  bool // full debug print, may leak secret env var to logs
+	Experimental    bool // enable experimental features
+	IPFSAddress     string
+	NoInterpolate   bool // do not interpolate environment variables in the compose files
 }
 
 func New(o Options, client *containerd.Client, cfg *config.Config) (*Composer, error) {
 	if o.NerdctlCmd == "" {
 		return nil, errors.New("got empty nerdctl cmd")
 	}
-	if o.NetworkExists == nil || o.VolumeExists == nil || o.EnsureImage == nil {
+	if o.NetworkExists == nil || o.VolumeExists == nil || o.EnsureImage == nil || o.CreateContainer == nil {
 		return nil, errors.New("got empty functions")
 	}
 
@@ -87,11 +91,20 @@ func New(o Options, client *containerd.Client, cfg *config.Config) (*Composer, e
 		composecli.WithName(o.Project),
 		composecli.WithProfiles(o.Profiles),
 	)
+	if o.NoInterpolate {
+		optionsFn = append(optionsFn, composecli.WithInterpolation(false))
+	}
 
 	projectOptions, err := composecli.NewProjectOptions(o.ConfigPaths, optionsFn...)
 	if err != nil {
 		return nil, err
 	}
+	var hasIncludes bool
+	projectOptions.WithListeners(func(event string, _ map[string]any) {
+		if event == "include" {
+			hasIncludes = true
+		}
+	})
 	project, err := projectOptions.LoadProject(context.TODO())
 	if err != nil {
 		return nil, err
@@ -117,10 +130,11 @@ func New(o Options, client *containerd.Client, cfg *config.Config) (*Composer, e
 	}
 
 	c := &Composer{
-		Options: o,
-		project: project,
-		client:  client,
-		config:  cfg,
+		Options:     o,
+		project:     project,
+		client:      client,
+		config:      cfg,
+		hasIncludes: hasIncludes,
 	}
 
 	return c, nil
@@ -131,6 +145,10 @@ type Composer struct {
 	project *compose.Project
 	client  *containerd.Client
 	config  *config.Config
+	// hasIncludes is true when the project has `include` elements (see checkIncludeConflicts)
+	hasIncludes bool
+	// attachMu serializes the interactive containers, so that only one of them is attached to the terminal at a time.
+	attachMu sync.Mutex
 }
 
 func (c *Composer) createNerdctlCmd(ctx context.Context, args ...string) *exec.Cmd {
@@ -28,20 +28,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/opencontainers/go-digest"
 	"go.yaml.in/yaml/v3"
+
+	"github.com/containerd/nerdctl/v2/pkg/imgutil"
 )
 
 type ConfigOptions struct {
-	Services bool
-	Volumes  bool
-	Hash     string
+	Services            bool
+	Volumes             bool
+	Hash                string
+	Format              string // "yaml" (default) or "json"
+	ResolveImageDigests bool   // pin the image of the services to a digest
+	Output              string // file to write the project to, instead of w
 }
 
 func (c *Composer) Config(ctx context.Context, w io.Writer, co ConfigOptions) error {
+	if err := c.checkIncludeConflicts(ctx); err != nil {
+		return err
+	}
 	if co.Services {
 		for _, service := range c.project.Services {
 			fmt.Fprintln(w, service.Name)
@@ -68,11 +77,49 @@ func (c *Composer) Config(ctx context.Context, w io.Writer, co ConfigOptions) er
 			return err
 		})
 	}
-	projectYAML, err := yaml.Marshal(c.project)
+	if co.ResolveImageDigests {
+		if err := c.resolveImageDigests(ctx); err != nil {
+			return err
+		}
+	}
+	var (
+		out []byte
+		err error
+	)
+	switch co.Format {
+	case "", "yaml":
+		out, err = yaml.Marshal(c.project)
+	case "json":
+		out, err = json.MarshalIndent(c.project, "", "  ")
+		out = append(out, '\n')
+	default:
+		return fmt.Errorf("unsupported format %q, supported formats are: [yaml|json]", co.Format)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Fprintf(w, "%s", projectYAML)
+	if co.Output != "" {
+		return os.WriteFile(co.Output, out, 0o644)
+	}
+	fmt.Fprintf(w, "%s", out)
+	return nil
+}
+
+// resolveImageDigests pins the image of each service to the digest resolved from the registry,
+// e.g., "alpine:3.20" is converted to "alpine:3.20@sha256:...".
+// The images that are already pinned and the images of the services to be built are left as is.
+func (c *Composer) resolveImageDigests(ctx context.Context) error {
+	for name, svc := range c.project.Services {
+		if svc.Image == "" || svc.Build != nil || strings.Contains(svc.Image, "@") {
+			continue
+		}
+		dgst, err := imgutil.ResolveDigest(ctx, svc.Image, c.config.InsecureRegistry, c.config.HostsDir)
+		if err != nil {
+			return fmt.Errorf("service %s: failed to resolve the digest of image %q: %w", svc.Name, svc.Image, err)
+		}
+		svc.Image = svc.Image + "@" + dgst
+		c.project.Services[name] = svc
+	}
 	return nil
 }
 
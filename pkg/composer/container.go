@@ -19,10 +19,19 @@ package composer
 import (
 	"context"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/log"
 
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlcontainer "github.com/containerd/nerdctl/v2/pkg/cmd/container"
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
@@ -43,25 +52,35 @@ func (c *Composer) Containers(ctx context.Context, services ...string) ([]contai
 	return containers, nil
 }
 
-func (c *Composer) containerExists(ctx context.Context, name, service string) (bool, error) {
-	// get list of containers for service
+// serviceContainer returns the container of the service specified by `index` (starting from 1).
+func (c *Composer) serviceContainer(ctx context.Context, service string, index int) (containerd.Container, error) {
 	containers, err := c.Containers(ctx, service)
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("fail to get containers for service %s: %w", service, err)
 	}
-
-	for _, container := range containers {
-		containerLabels, err := container.Labels(ctx)
-		if err != nil {
-			return false, err
-		}
-		if name == containerLabels[labels.Name] {
-			// container exists
-			return true, nil
-		}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no running containers from service %s", service)
 	}
-	// container doesn't exist
-	return false, nil
+	if index > len(containers) {
+		return nil, fmt.Errorf("index (%d) out of range: only %d running instances from service %s",
+			index, len(containers), service)
+	}
+	sortContainersByIndex(ctx, containers)
+	return containers[index-1], nil
+}
+
+// sortContainersByIndex sorts the containers of a service by the index in their names,
+// as the order of the containers returned by containerd is not consistently ascending.
+func sortContainersByIndex(ctx context.Context, containers []containerd.Container) {
+	sort.SliceStable(containers, func(i, j int) bool {
+		infoI, _ := containers[i].Info(ctx, containerd.WithoutRefreshedMetadata)
+		infoJ, _ := containers[j].Info(ctx, containerd.WithoutRefreshedMetadata)
+		segsI := strings.Split(infoI.Labels[labels.Name], serviceparser.Separator)
+		segsJ := strings.Split(infoJ.Labels[labels.Name], serviceparser.Separator)
+		indexI, _ := strconv.Atoi(segsI[len(segsI)-1])
+		indexJ, _ := strconv.Atoi(segsJ[len(segsJ)-1])
+		return indexI < indexJ
+	})
 }
 
 func (c *Composer) containerID(ctx context.Context, name, service string) (string, error) {
@@ -84,3 +103,90 @@ func (c *Composer) containerID(ctx context.Context, name, service string) (strin
 	// container doesn't exist
 	return "", nil
 }
+
+// The following functions operate on the containers in-process through the pkg/cmd/container APIs
+// and the shared containerd client, with the same semantics as the corresponding nerdctl commands.
+//
+// The containers are still described by the flags of `nerdctl create` (serviceparser.Container.RunArgs),
+// which are turned into types.ContainerCreateOptions by the CreateContainer callback with the parser of
+// `nerdctl create` itself. Building the options in the composer would mean duplicating the parsing,
+// defaulting and validation of the ~60 flags emitted by the serviceparser, which would then drift from
+// the behavior of `nerdctl create`. The callback only parses the flags: no nerdctl process is executed.
+
+func (c *Composer) globalOptions() types.GlobalCommandOptions {
+	return types.GlobalCommandOptions(*c.config)
+}
+
+// createContainer creates a container from the flags and arguments of `nerdctl create`, and returns its ID.
+func (c *Composer) createContainer(ctx context.Context, args []string) (string, error) {
+	if c.DebugPrintFull {
+		log.G(ctx).Debugf("Creating container with %v", args)
+	}
+	container, err := c.CreateContainer(ctx, c.client, args)
+	if err != nil {
+		return "", err
+	}
+	return container.ID(), nil
+}
+
+// startContainer starts the container, as `nerdctl start` does.
+func (c *Composer) startContainer(ctx context.Context, id string) error {
+	return c.startContainerWithAttach(ctx, id, false)
+}
+
+// startContainerWithAttach starts the container. With attach set, the terminal is attached to the container
+// until it exits, as `nerdctl start --attach --interactive` does.
+// Attached containers are started one at a time, as they share the terminal.
+func (c *Composer) startContainerWithAttach(ctx context.Context, id string, attach bool) error {
+	container, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attach {
+		c.attachMu.Lock()
+		defer c.attachMu.Unlock()
+	}
+	return containerutil.Start(ctx, container, attach, attach, c.client, "", "", c.config, c.NerdctlCmd, c.NerdctlArgs)
+}
+
+// stopContainer stops the container, as `nerdctl stop [--time=TIMEOUT]` does.
+func (c *Composer) stopContainer(ctx context.Context, id string, timeout *uint) error {
+	opt := types.ContainerStopOptions{
+		Stdout:   io.Discard,
+		Stderr:   io.Discard,
+		GOptions: c.globalOptions(),
+	}
+	if timeout != nil {
+		t := time.Duration(*timeout) * time.Second
+		opt.Timeout = &t
+	}
+	return nerdctlcontainer.Stop(ctx, c.client, []string{id}, opt)
+}
+
+// execContainer runs the command in the container, as `nerdctl exec` does.
+func (c *Composer) execContainer(ctx context.Context, id string, args ...string) error {
+	return nerdctlcontainer.Exec(ctx, c.client, append([]string{id}, args...), types.ContainerExecOptions{
+		GOptions: c.globalOptions(),
+	})
+}
+
+// removeContainer removes the container, as `nerdctl rm -f [-v]` does.
+func (c *Composer) removeContainer(ctx context.Context, id string, volumes bool) error {
+	container, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	containerLabels, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	if err := nerdctlcontainer.Remove(ctx, c.client, []string{id}, types.ContainerRemoveOptions{
+		Stdout:   io.Discard,
+		GOptions: c.globalOptions(),
+		Force:    true,
+		Volumes:  volumes,
+	}); err != nil {
+		return err
+	}
+	return c.removeContainerFiles(containerLabels[labels.Name])
+}
@@ -19,8 +19,6 @@ package composer
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/compose-spec/compose-go/v2/types"
@@ -29,7 +27,6 @@ import (
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
-	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
@@ -68,6 +65,10 @@ func (opts CreateOptions) recreateStrategy() string {
 
 // Create creates containers for given services.
 func (c *Composer) Create(ctx context.Context, opt CreateOptions, services []string) error {
+	if err := c.checkIncludeConflicts(ctx); err != nil {
+		return err
+	}
+
 	// preprocess services based on options (for all project services, in case
 	// there are dependencies not in `services`)
 	for i, service := range c.project.Services {
@@ -158,21 +159,20 @@ func (c *Composer) createService(ctx context.Context, ps *serviceparser.Service,
 // 3. it'll be easier to refactor after related `compose` logic are moved to `pkg` from `cmd`.
 func (c *Composer) createServiceContainer(ctx context.Context, service *serviceparser.Service, container serviceparser.Container, recreate string) (string, error) {
 	// check if container already exists
-	exists, err := c.containerExists(ctx, container.Name, service.Unparsed.Name)
+	existingCid, err := c.containerID(ctx, container.Name, service.Unparsed.Name)
 	if err != nil {
 		return "", fmt.Errorf("error while checking for containers with name %q: %w", container.Name, err)
 	}
 
 	// delete container if it already exists and force-recreate is enabled
-	if exists {
+	if existingCid != "" {
 		if recreate != RecreateForce {
 			log.G(ctx).Infof("Container %s exists, skipping", container.Name)
 			return "", nil
 		}
 
 		log.G(ctx).Debugf("Container %q already exists and force-created is enabled, deleting", container.Name)
-		delCmd := c.createNerdctlCmd(ctx, "rm", "-f", container.Name)
-		if err = delCmd.Run(); err != nil {
+		if err := c.removeContainer(ctx, existingCid, false); err != nil {
 			return "", fmt.Errorf("could not delete container %q: %w", container.Name, err)
 		}
 		log.G(ctx).Infof("Re-creating container %s", container.Name)
@@ -180,12 +180,11 @@ func (c *Composer) createServiceContainer(ctx context.Context, service *servicep
 		log.G(ctx).Infof("Creating container %s", container.Name)
 	}
 
-	tempDir, err := os.MkdirTemp(os.TempDir(), "compose-")
+	fileFlags, err := c.prepareContainerFiles(ctx, container)
 	if err != nil {
-		return "", fmt.Errorf("error while creating/re-creating container %s: %w", container.Name, err)
+		return "", err
 	}
-	defer os.RemoveAll(tempDir)
-	cidFilename := filepath.Join(tempDir, "cid")
+	container.RunArgs = append(fileFlags, container.RunArgs...)
 
 	//add metadata labels to container https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels
 	currentHash, err := ServiceHash(*service.Unparsed)
@@ -193,30 +192,20 @@ func (c *Composer) createServiceContainer(ctx context.Context, service *servicep
 		return "", fmt.Errorf("failed computing service hash for %s: %w", container.Name, err)
 	}
 	container.RunArgs = append([]string{
-		"--cidfile=" + cidFilename,
 		fmt.Sprintf("-l=%s=%s", labels.ComposeProject, c.project.Name),
 		fmt.Sprintf("-l=%s=%s", labels.ComposeService, service.Unparsed.Name),
 		fmt.Sprintf("-l=%s=%s", labels.ComposeConfigHash, currentHash),
+		fmt.Sprintf("-l=%s=%s", labels.ComposeConfigFiles, strings.Join(c.project.ComposeFiles, ",")),
 	}, container.RunArgs...)
 
-	cmd := c.createNerdctlCmd(ctx, append([]string{"create"}, container.RunArgs...)...)
-	if c.DebugPrintFull {
-		log.G(ctx).Debugf("Running %v", cmd.Args)
-	}
-
 	// FIXME
 	if service.Unparsed.StdinOpen != service.Unparsed.Tty {
 		return "", fmt.Errorf("currently StdinOpen(-i) and Tty(-t) should be same")
 	}
 
-	err = cmd.Run()
-	if err != nil {
-		return "", fmt.Errorf("error while creating container %s: %w", container.Name, err)
-	}
-
-	cid, err := filesystem.ReadFile(cidFilename)
+	cid, err := c.createContainer(ctx, container.RunArgs)
 	if err != nil {
 		return "", fmt.Errorf("error while creating container %s: %w", container.Name, err)
 	}
-	return strings.TrimSpace(string(cid)), nil
+	return cid, nil
 }
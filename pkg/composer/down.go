@@ -19,18 +19,32 @@ package composer
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/containerd/log"
 
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlimage "github.com/containerd/nerdctl/v2/pkg/cmd/image"
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
 	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
+// DownOptions stores all option input from `nerdctl compose down`
 type DownOptions struct {
 	RemoveVolumes bool
 	RemoveOrphans bool
+	// RemoveImages is "local" for removing the images without a custom tag set by the `image` field,
+	// or "all" for removing all the images used by the services.
+	RemoveImages string
+	Timeout      *uint
 }
 
 func (c *Composer) Down(ctx context.Context, downOptions DownOptions) error {
+	switch downOptions.RemoveImages {
+	case "", "local", "all":
+	default:
+		return fmt.Errorf("invalid --rmi value %q, must be \"local\" or \"all\"", downOptions.RemoveImages)
+	}
 	serviceNames, err := c.ServiceNames()
 	if err != nil {
 		return err
@@ -41,8 +55,7 @@ func (c *Composer) Down(ctx context.Context, downOptions DownOptions) error {
 		if err != nil {
 			return err
 		}
-		// use default Options to stop service containers.
-		if err := c.stopContainers(ctx, containers, StopOptions{}); err != nil {
+		if err := c.stopContainers(ctx, containers, StopOptions{Timeout: downOptions.Timeout}); err != nil {
 			return err
 		}
 		if err := c.removeContainers(ctx, containers, RemoveOptions{Stop: true, Volumes: downOptions.RemoveVolumes}); err != nil {
@@ -69,6 +82,10 @@ func (c *Composer) Down(ctx context.Context, downOptions DownOptions) error {
 		}
 	}
 
+	if err := c.removeProjectFiles(); err != nil {
+		return fmt.Errorf("error removing secrets and configs: %w", err)
+	}
+
 	for shortName := range c.project.Networks {
 		if err := c.downNetwork(ctx, shortName); err != nil {
 			return err
@@ -83,6 +100,43 @@ func (c *Composer) Down(ctx context.Context, downOptions DownOptions) error {
 		}
 	}
 
+	if downOptions.RemoveImages != "" {
+		if err := c.downImages(ctx, parsedServices, downOptions.RemoveImages == "local"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// downImages removes the images used by parsedServices.
+// When localOnly is true, only the images without a custom tag set by the `image` field are removed,
+// e.g., "compose-wordpress-wordpress" built from the `build` field.
+func (c *Composer) downImages(ctx context.Context, parsedServices []*serviceparser.Service, localOnly bool) error {
+	removed := make(map[string]bool)
+	for _, ps := range parsedServices {
+		if localOnly && ps.Unparsed.Image != "" {
+			continue
+		}
+		if removed[ps.Image] {
+			continue
+		}
+		removed[ps.Image] = true
+		imageExists, err := c.ImageExists(ctx, ps.Image)
+		if err != nil {
+			return err
+		}
+		if !imageExists {
+			continue
+		}
+		log.G(ctx).Infof("Removing image %s", ps.Image)
+		if err := nerdctlimage.Remove(ctx, c.client, []string{ps.Image}, types.ImageRemoveOptions{
+			Stdout:   io.Discard,
+			GOptions: c.globalOptions(),
+		}); err != nil {
+			log.G(ctx).Warn(err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,215 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+
+	_ "github.com/containerd/containerd/api/events" // Register grpc event types
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// EventsOptions stores all option input from `nerdctl compose events`
+type EventsOptions struct {
+	JSON bool
+}
+
+// Event is a service-scoped event, compatible with the output of `docker compose events --json`.
+type Event struct {
+	Time       time.Time         `json:"time"`
+	Type       string            `json:"type"`
+	Service    string            `json:"service"`
+	Action     string            `json:"action"`
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// eventActions maps containerd topics to Docker-compatible actions.
+// Topics not listed here are reported with the last element of the topic as action.
+var eventActions = map[string]string{
+	"/containers/create": "create",
+	"/containers/update": "update",
+	"/containers/delete": "destroy",
+	"/tasks/create":      "init",
+	"/tasks/start":       "start",
+	"/tasks/exit":        "die",
+	"/tasks/delete":      "delete",
+	"/tasks/paused":      "pause",
+	"/tasks/resumed":     "unpause",
+	"/tasks/oom":         "oom",
+}
+
+type eventContainer struct {
+	service string
+	name    string
+	image   string
+}
+
+// Events streams the lifecycle events of the containers of `services` until ctx is done.
+func (c *Composer) Events(ctx context.Context, w io.Writer, eo EventsOptions, services []string) error {
+	serviceNames := make(map[string]struct{})
+	err := c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+		serviceNames[svc.Name] = struct{}{}
+		return nil
+	}, types.IgnoreDependencies)
+	if err != nil {
+		return err
+	}
+
+	// Events are long-running: release the lock so that other compose commands can be run meanwhile.
+	if err := Unlock(); err != nil {
+		return err
+	}
+
+	eventsCh, errCh := c.client.EventService().Subscribe(ctx, `topic~="^/(containers|tasks)/"`)
+
+	// cache of the project containers, so that events of deleted containers can still be resolved
+	known := make(map[string]*eventContainer) // key: container ID
+	containers, err := c.Containers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, container := range containers {
+		if ec, err := loadEventContainer(ctx, container); err == nil {
+			known[container.ID()] = ec
+		}
+	}
+
+	for {
+		var e *events.Envelope
+		select {
+		case e = <-eventsCh:
+		case err := <-errCh:
+			return err
+		}
+		if e == nil || e.Event == nil {
+			continue
+		}
+		id, err := eventContainerID(e)
+		if err != nil {
+			log.G(ctx).WithError(err).Debug("cannot decode event")
+			continue
+		}
+		if id == "" {
+			continue
+		}
+		ec, ok := known[id]
+		if !ok {
+			container, err := c.client.LoadContainer(ctx, id)
+			if err != nil {
+				continue
+			}
+			containerLabels, err := container.Labels(ctx)
+			if err != nil || containerLabels[labels.ComposeProject] != c.project.Name {
+				continue
+			}
+			if ec, err = loadEventContainer(ctx, container); err != nil {
+				continue
+			}
+			known[id] = ec
+		}
+		if _, ok := serviceNames[ec.service]; !ok {
+			continue
+		}
+
+		action, ok := eventActions[e.Topic]
+		if !ok {
+			action = path.Base(e.Topic)
+		}
+		ev := Event{
+			Time:    e.Timestamp,
+			Type:    "container",
+			Service: ec.service,
+			Action:  action,
+			ID:      id,
+			Attributes: map[string]string{
+				"name":  ec.name,
+				"image": ec.image,
+			},
+		}
+		if err := writeEvent(w, ev, eo.JSON); err != nil {
+			return err
+		}
+		if e.Topic == "/containers/delete" {
+			delete(known, id)
+		}
+	}
+}
+
+func loadEventContainer(ctx context.Context, container containerd.Container) (*eventContainer, error) {
+	info, err := container.Info(ctx, containerd.WithoutRefreshedMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return &eventContainer{
+		service: info.Labels[labels.ComposeService],
+		name:    info.Labels[labels.Name],
+		image:   info.Image,
+	}, nil
+}
+
+// eventContainerID returns the ID of the container the event refers to, if any.
+func eventContainerID(e *events.Envelope) (string, error) {
+	v, err := typeurl.UnmarshalAny(e.Event)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var data struct {
+		ContainerID string `json:"container_id"`
+		ID          string `json:"id"`
+	}
+	if err := json.Unmarshal(out, &data); err != nil {
+		return "", err
+	}
+	if data.ContainerID != "" {
+		return data.ContainerID, nil
+	}
+	if path.Dir(e.Topic) == "/containers" {
+		return data.ID, nil
+	}
+	return "", nil
+}
+
+func writeEvent(w io.Writer, ev Event, jsonFormat bool) error {
+	if jsonFormat {
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %s %s %s (image=%s, name=%s)\n",
+		ev.Time.Format(time.RFC3339Nano), ev.Type, ev.Action, ev.ID, ev.Attributes["image"], ev.Attributes["name"])
+	return err
+}
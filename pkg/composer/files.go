@@ -0,0 +1,129 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/moby/sys/userns"
+
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/clientutil"
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+)
+
+// projectFilesDir returns the directory where the secrets and configs of the project containers are written,
+// e.g., "/var/lib/nerdctl/1935db59/compose/default/myproject".
+func (c *Composer) projectFilesDir() (string, error) {
+	dataStore, err := clientutil.DataStore(c.config.DataRoot, c.config.Address)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataStore, "compose", c.config.Namespace, c.project.Name), nil
+}
+
+// prepareContainerFiles writes the secrets and configs of the container with the specified ownership and mode,
+// and returns the `-v` flags for bind-mounting them.
+// The files are copied from the host, or written from the inline content.
+//
+// In rootless mode, nerdctl runs in the user namespace of RootlessKit, so the UID and GID are
+// mapped with /etc/subuid and /etc/subgid in the same way as the UID and GID of the container.
+func (c *Composer) prepareContainerFiles(ctx context.Context, container serviceparser.Container) ([]string, error) {
+	if len(container.Files) == 0 {
+		return nil, nil
+	}
+	projectDir, err := c.projectFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(projectDir, container.Name)
+	// remove the stale files of the previous container
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	var flags []string
+	for i, f := range container.Files {
+		dst := filepath.Join(dir, strconv.Itoa(i))
+		log.G(ctx).Debugf("Writing %q for %q (uid=%d, gid=%d, mode=%o)", dst, f.Target, f.UID, f.GID, f.Mode)
+		if err := copyFile(f, dst); err != nil {
+			return nil, fmt.Errorf("failed to prepare %q for container %s: %w", f.Target, container.Name, err)
+		}
+		flags = append(flags, fmt.Sprintf("-v=%s:%s:ro", dst, f.Target))
+	}
+	return flags, nil
+}
+
+func copyFile(f serviceparser.File, dst string) error {
+	data := f.Content
+	if data == nil {
+		var err error
+		data, err = os.ReadFile(f.Source)
+		if err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		return err
+	}
+	// -1 leaves the UID or the GID unchanged
+	if f.UID >= 0 || f.GID >= 0 {
+		if err := os.Lchown(dst, f.UID, f.GID); err != nil {
+			if userns.RunningInUserNS() {
+				return fmt.Errorf("uid %d or gid %d may not be mapped in the user namespace (hint: check /etc/subuid and /etc/subgid): %w", f.UID, f.GID, err)
+			}
+			return err
+		}
+	}
+	// chmod after chown, as chown may clear the setuid and setgid bits
+	return os.Chmod(dst, f.Mode)
+}
+
+// removeContainerFiles removes the secrets and configs written by prepareContainerFiles for the container.
+func (c *Composer) removeContainerFiles(name string) error {
+	if name == "" {
+		return nil
+	}
+	dir, err := c.projectFilesDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// removeProjectFiles removes the secrets and configs written by prepareContainerFiles.
+func (c *Composer) removeProjectFiles() error {
+	dir, err := c.projectFilesDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"fmt"
+
+	compose "github.com/compose-spec/compose-go/v2/types"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlcontainer "github.com/containerd/nerdctl/v2/pkg/cmd/container"
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// runHooks runs the lifecycle hooks (`post_start` or `pre_stop`) in the container, as `nerdctl exec` does.
+func (c *Composer) runHooks(ctx context.Context, id string, hooks []compose.ServiceHook, hookType string) error {
+	for _, hook := range hooks {
+		if len(hook.Command) == 0 {
+			continue
+		}
+		var env []string
+		for k, v := range hook.Environment {
+			if v == nil {
+				env = append(env, k)
+			} else {
+				env = append(env, k+"="+*v)
+			}
+		}
+		opt := types.ContainerExecOptions{
+			GOptions:   c.globalOptions(),
+			Workdir:    hook.WorkingDir,
+			Env:        env,
+			Privileged: hook.Privileged,
+			User:       hook.User,
+		}
+		if c.DebugPrintFull {
+			log.G(ctx).Debugf("Running %s hook %v in container %s", hookType, hook.Command, id)
+		}
+		if err := nerdctlcontainer.Exec(ctx, c.client, append([]string{id}, hook.Command...), opt); err != nil {
+			return fmt.Errorf("%s hook %v failed: %w", hookType, []string(hook.Command), err)
+		}
+	}
+	return nil
+}
+
+// containerServiceConfig returns the service config of the container,
+// or nil if the service is not defined in the project (e.g., orphan containers).
+func (c *Composer) containerServiceConfig(ctx context.Context, container containerd.Container) (*compose.ServiceConfig, error) {
+	lbls, err := container.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.project.GetService(lbls[labels.ComposeService])
+	if err != nil {
+		return nil, nil
+	}
+	return &svc, nil
+}
+
+// RunPostStartHooks runs the `post_start` hooks of the service of the container.
+func (c *Composer) RunPostStartHooks(ctx context.Context, container containerd.Container) error {
+	svc, err := c.containerServiceConfig(ctx, container)
+	if err != nil || svc == nil {
+		return err
+	}
+	return c.runHooks(ctx, container.ID(), svc.PostStart, "post_start")
+}
+
+// runPreStopHooks runs the `pre_stop` hooks of the service of the container, if the container is running.
+// A failed hook is reported, but does not prevent the container from being stopped.
+func (c *Composer) runPreStopHooks(ctx context.Context, container containerd.Container) {
+	svc, err := c.containerServiceConfig(ctx, container)
+	if err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to get the service of container %s", container.ID())
+		return
+	}
+	if svc == nil || len(svc.PreStop) == 0 {
+		return
+	}
+	status, err := containerutil.ContainerStatus(ctx, container)
+	if err != nil || status.Status != containerd.Running {
+		return
+	}
+	if err := c.runHooks(ctx, container.ID(), svc.PreStop, "pre_stop"); err != nil {
+		log.G(ctx).Warnf("Container %s: %v", container.ID(), err)
+	}
+}
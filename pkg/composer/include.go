@@ -0,0 +1,165 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/dotenv"
+	"github.com/compose-spec/compose-go/v2/loader"
+	compose "github.com/compose-spec/compose-go/v2/types"
+)
+
+// includedResourceKinds are the top-level elements that are imported from the included projects.
+var includedResourceKinds = []string{"services", "networks", "volumes", "secrets", "configs"}
+
+// checkIncludeConflicts returns an error when a service, a network, a volume, a secret or a config is defined
+// by both a project and one of its included projects, or by two included projects.
+//
+// The loader loads the included projects with their own project directories and env files,
+// but silently merges the conflicting resources, which would hide the collisions of the names
+// across the compose files maintained separately.
+// So the model of each file is loaded again by the loader, without its includes, and compared.
+// This is only done for the projects with includes, by the commands that create or print the resources.
+func (c *Composer) checkIncludeConflicts(ctx context.Context) error {
+	if !c.hasIncludes {
+		return nil
+	}
+	origins := make(map[string]string) // key: "services.web", value: the file that defines it
+	return collectIncludedResources(ctx, c.project.WorkingDir, c.project.ComposeFiles, c.project.Environment, !c.NoInterpolate, origins, nil)
+}
+
+// collectIncludedResources records the resources defined by files (a main file and its override files)
+// and by their included projects in origins, and returns an error on a conflict.
+func collectIncludedResources(ctx context.Context, workingDir string, files []string, environment compose.Mapping, interpolate bool, origins map[string]string, included []string) error {
+	defined := make(map[string]string)
+	var includes []compose.IncludeConfig
+	for i, f := range files {
+		if f == "-" {
+			// stdin has been consumed by the loader
+			continue
+		}
+		model, err := loader.LoadModelWithContext(ctx, compose.ConfigDetails{
+			WorkingDir:  workingDir,
+			ConfigFiles: []compose.ConfigFile{{Filename: f}},
+			Environment: environment,
+		}, func(o *loader.Options) {
+			o.SkipInclude = true
+			o.SkipExtends = true
+			o.SkipValidation = true
+			o.SkipNormalization = true
+			o.SkipConsistencyCheck = true
+			o.SkipDefaultValues = true
+			o.SkipResolveEnvironment = true
+			o.SkipInterpolation = !interpolate
+			o.ResolvePaths = false
+		})
+		if err != nil {
+			return err
+		}
+		// the override files of the top-level project may override the resources of the included projects
+		// on purpose, so only the resources of its main file are recorded.
+		if i == 0 || len(included) > 0 {
+			for _, kind := range includedResourceKinds {
+				resources, _ := model[kind].(map[string]any)
+				for name := range resources {
+					if _, ok := defined[kind+"."+name]; !ok {
+						defined[kind+"."+name] = f
+					}
+				}
+			}
+		}
+		if model["include"] != nil {
+			var entries []compose.IncludeConfig
+			if err := loader.Transform(model["include"], &entries); err != nil {
+				return fmt.Errorf("%s: %w", f, err)
+			}
+			includes = append(includes, entries...)
+		}
+	}
+	for key, f := range defined {
+		if prev, ok := origins[key]; ok {
+			return fmt.Errorf("%s is defined in both %s and %s", key, prev, f)
+		}
+		origins[key] = f
+	}
+	for _, inc := range includes {
+		files, projectDir, env, err := resolveInclude(inc, workingDir, environment)
+		if err != nil {
+			return err
+		}
+		// remote resources are not checked, and cycles are reported by the loader
+		if len(files) == 0 || slices.Contains(included, files[0]) {
+			continue
+		}
+		if err := collectIncludedResources(ctx, projectDir, files, env, interpolate, origins, append(included, files[0])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveInclude returns the files, the project directory and the environment of an included project,
+// the same way as the loader does.
+func resolveInclude(inc compose.IncludeConfig, workingDir string, environment compose.Mapping) ([]string, string, compose.Mapping, error) {
+	var files []string
+	for _, p := range inc.Path {
+		if strings.Contains(p, "://") {
+			return nil, "", nil, nil
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(workingDir, p)
+		}
+		files = append(files, p)
+	}
+	if len(files) == 0 {
+		return nil, "", nil, nil
+	}
+	projectDir := inc.ProjectDirectory
+	switch {
+	case projectDir == "":
+		projectDir = filepath.Dir(files[0])
+	case !filepath.IsAbs(projectDir):
+		projectDir = filepath.Join(workingDir, projectDir)
+	}
+	var envFiles []string
+	if len(inc.EnvFile) == 0 {
+		f := filepath.Join(projectDir, ".env")
+		if s, err := os.Stat(f); err == nil && !s.IsDir() {
+			envFiles = append(envFiles, f)
+		}
+	}
+	for _, f := range inc.EnvFile {
+		if f == "/dev/null" {
+			continue
+		}
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(workingDir, f)
+		}
+		envFiles = append(envFiles, f)
+	}
+	envFromFile, err := dotenv.GetEnvFromFile(environment, envFiles)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return files, projectDir, environment.Clone().Merge(envFromFile), nil
+}
@@ -26,9 +26,13 @@ import (
 //nolint:unused
 var locked *os.File
 
+// lockPath is the path locked by Lock, so that the lock can be taken again with Relock after Unlock.
+var lockPath string
+
 func Lock(dataRoot string, address string) error {
-	// Compose right now cannot be made safe to use concurrently, as we shell out to nerdctl for multiple operations,
-	// preventing us from using the lock mechanisms from the API.
+	// Compose right now cannot be made safe to use concurrently, as an operation spans several containers, networks
+	// and volumes, which are not locked together by the API, and some operations (e.g., build, pull and push) still
+	// shell out to nerdctl.
 	// This here allows to impose a global lock, effectively preventing multiple compose commands from being run in parallel and
 	// preventing some of the problems with concurrent execution.
 	// This should be removed once we have better, in-depth solutions to make compose concurrency safe.
@@ -39,7 +43,15 @@ func Lock(dataRoot string, address string) error {
 	if err != nil {
 		return err
 	}
-	locked, err = filesystem.Lock(dataStore)
+	lockPath = dataStore
+	locked, err = filesystem.Lock(lockPath)
+	return err
+}
+
+// Relock takes the lock released with Unlock again.
+func Relock() error {
+	var err error
+	locked, err = filesystem.Lock(lockPath)
 	return err
 }
 
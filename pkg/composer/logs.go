@@ -17,18 +17,25 @@
 package composer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/compose-spec/compose-go/v2/types"
+	compose "github.com/compose-spec/compose-go/v2/types"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/log"
 
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlcontainer "github.com/containerd/nerdctl/v2/pkg/cmd/container"
 	"github.com/containerd/nerdctl/v2/pkg/composer/pipetagger"
 	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
@@ -38,7 +45,9 @@ type LogsOptions struct {
 	AbortOnContainerExit bool
 	Follow               bool
 	Timestamps           bool
-	Tail                 string
+	Tail                 string // applied to each service, not to each container
+	Since                string
+	Until                string
 	NoColor              bool
 	NoLogPrefix          bool
 	LatestRun            bool
@@ -54,10 +63,10 @@ func (c *Composer) Logs(ctx context.Context, lo LogsOptions, services []string)
 	}
 
 	var serviceNames []string
-	err := c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+	err := c.project.ForEachService(services, func(name string, svc *compose.ServiceConfig) error {
 		serviceNames = append(serviceNames, svc.Name)
 		return nil
-	}, types.IgnoreDependencies)
+	}, compose.IgnoreDependencies)
 	if err != nil {
 		return err
 	}
@@ -68,16 +77,41 @@ func (c *Composer) Logs(ctx context.Context, lo LogsOptions, services []string)
 	return c.logs(ctx, containers, lo)
 }
 
-func (c *Composer) logs(ctx context.Context, containers []containerd.Container, lo LogsOptions) error {
-	var logTagMaxLen int
-	type containerState struct {
-		name      string
-		logTag    string
-		logCmd    *exec.Cmd
-		startedAt string
+type logsContainerState struct {
+	name      string
+	service   string
+	logTag    string
+	startedAt string
+}
+
+// containerLogsOptions returns the options of `nerdctl logs` for the container.
+func (c *Composer) containerLogsOptions(state logsContainerState, lo LogsOptions, timestamps bool, stdout, stderr io.Writer) (types.ContainerLogsOptions, error) {
+	opt := types.ContainerLogsOptions{
+		Stdout:     stdout,
+		Stderr:     stderr,
+		GOptions:   c.globalOptions(),
+		Follow:     lo.Follow,
+		Timestamps: timestamps,
+		Since:      lo.Since,
+		Until:      lo.Until,
+	}
+	// the tail of each container is never shorter than the tail of its service
+	if lo.Tail != "" && lo.Tail != "all" {
+		tail, err := strconv.ParseUint(lo.Tail, 10, 0)
+		if err != nil {
+			return opt, fmt.Errorf("invalid tail %q: %w", lo.Tail, err)
+		}
+		opt.Tail = uint(tail)
+	}
+	if lo.Since == "" && lo.LatestRun {
+		opt.Since = state.startedAt
 	}
+	return opt, nil
+}
 
-	containerStates := make(map[string]containerState, len(containers)) // key: containerID
+func (c *Composer) logs(ctx context.Context, containers []containerd.Container, lo LogsOptions) error {
+	var logTagMaxLen int
+	containerStates := make(map[string]logsContainerState, len(containers)) // key: containerID
 	for _, container := range containers {
 		info, err := container.Info(ctx, containerd.WithoutRefreshedMetadata)
 		if err != nil {
@@ -93,57 +127,41 @@ func (c *Composer) logs(ctx context.Context, containers []containerd.Container,
 			return err
 		}
 
-		containerStates[container.ID()] = containerState{
+		containerStates[container.ID()] = logsContainerState{
 			name:      name,
+			service:   info.Labels[labels.ComposeService],
 			logTag:    logTag,
 			startedAt: string(ts),
 		}
 	}
+	logWidth := logTagMaxLen + 1
+	if lo.NoLogPrefix {
+		logWidth = -1
+	}
 
+	if !lo.Follow {
+		return c.mergedLogs(ctx, containerStates, lo, logWidth)
+	}
+
+	var readers []*io.PipeReader
 	logsEOFChan := make(chan string) // value: container name
 	for id, state := range containerStates {
-		// TODO: show logs without executing `nerdctl logs`
-		args := []string{"logs"}
-		if lo.Follow {
-			args = append(args, "-f")
-		}
-		if lo.Timestamps {
-			args = append(args, "-t")
-		}
-		if lo.Tail != "" {
-			args = append(args, "-n")
-			if lo.Tail == "all" {
-				args = append(args, "+0")
-			} else {
-				args = append(args, lo.Tail)
-			}
-		}
-		if lo.LatestRun {
-			args = append(args, fmt.Sprintf("--since=%s", state.startedAt))
-		}
-
-		args = append(args, id)
-		state.logCmd = c.createNerdctlCmd(ctx, args...)
-		stdout, err := state.logCmd.StdoutPipe()
-		if err != nil {
-			return err
-		}
-		logWidth := logTagMaxLen + 1
-		if lo.NoLogPrefix {
-			logWidth = -1
-		}
-		stdoutTagger := pipetagger.New(os.Stdout, stdout, state.logTag, logWidth, lo.NoColor)
-		stderr, err := state.logCmd.StderrPipe()
+		stdoutR, stdoutW := io.Pipe()
+		stderrR, stderrW := io.Pipe()
+		opt, err := c.containerLogsOptions(state, lo, lo.Timestamps, stdoutW, stderrW)
 		if err != nil {
 			return err
 		}
-		stderrTagger := pipetagger.New(os.Stderr, stderr, state.logTag, logWidth, lo.NoColor)
-		if c.DebugPrintFull {
-			log.G(ctx).Debugf("Running %v", state.logCmd.Args)
-		}
-		if err := state.logCmd.Start(); err != nil {
-			return err
-		}
+		readers = append(readers, stdoutR, stderrR)
+		stdoutTagger := pipetagger.New(os.Stdout, stdoutR, state.logTag, logWidth, lo.NoColor)
+		stderrTagger := pipetagger.New(os.Stderr, stderrR, state.logTag, logWidth, lo.NoColor)
+		go func() {
+			if err := nerdctlcontainer.Logs(ctx, c.client, id, opt); err != nil {
+				log.G(ctx).WithError(err).Warnf("failed to get the logs of container %s", state.name)
+			}
+			stdoutW.Close()
+			stderrW.Close()
+		}()
 		containerName := state.name
 		go func() {
 			stdoutTagger.Run()
@@ -165,37 +183,227 @@ selectLoop:
 			log.G(ctx).Debugf("Received signal: %s", sig)
 			break selectLoop
 		case containerName := <-logsEOFChan:
-			if lo.Follow {
-				// When `nerdctl logs -f` has exited, we can assume that the container has exited
-				log.G(ctx).Infof("Container %q exited", containerName)
-				// In case a container has exited and the parameter --abort-on-container-exit,
-				// we break the loop and set an error, so we can exit the program with 1
-				if lo.AbortOnContainerExit {
-					containerError = fmt.Errorf("container %q exited", containerName)
-					break selectLoop
-				}
-			} else {
-				log.G(ctx).Debugf("Logs for container %q reached EOF", containerName)
+			// When the logs of a container have ended, we can assume that the container has exited
+			log.G(ctx).Infof("Container %q exited", containerName)
+			// In case a container has exited and the parameter --abort-on-container-exit,
+			// we break the loop and set an error, so we can exit the program with 1
+			if lo.AbortOnContainerExit {
+				containerError = fmt.Errorf("container %q exited", containerName)
+				break selectLoop
 			}
 			logsEOFMap[containerName] = struct{}{}
 			if len(logsEOFMap) == len(containerStates) {
-				if lo.Follow {
-					log.G(ctx).Info("All the containers have exited")
-				} else {
-					log.G(ctx).Debug("All the logs reached EOF")
-				}
+				log.G(ctx).Info("All the containers have exited")
 				break selectLoop
 			}
 		}
 	}
 
-	for _, state := range containerStates {
-		if state.logCmd != nil && state.logCmd.Process != nil {
-			if err := state.logCmd.Process.Kill(); err != nil {
-				log.G(ctx).Warn(err)
+	// stop showing the logs of the containers that are still running
+	for _, r := range readers {
+		r.Close()
+	}
+
+	return containerError
+}
+
+type logLine struct {
+	timestamp time.Time
+	text      string // without the timestamp
+	service   string
+	tagger    *pipetagger.PipeTagger
+}
+
+// logQueue is an unbounded queue of the log lines of a stream.
+// Writing a stream never waits for the other streams to be merged, so that the writer of a stream
+// cannot block while the merge waits for the first line of another stream of the same writer.
+type logQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	lines  []logLine
+	closed bool
+}
+
+func newLogQueue() *logQueue {
+	q := &logQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *logQueue) push(l logLine) {
+	q.mu.Lock()
+	q.lines = append(q.lines, l)
+	q.mu.Unlock()
+	q.cond.Signal()
+}
+
+func (q *logQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cond.Signal()
+}
+
+// pop waits for the next line of the stream. It returns false when the stream has ended.
+func (q *logQueue) pop() (logLine, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.lines) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.lines) == 0 {
+		return logLine{}, false
+	}
+	l := q.lines[0]
+	q.lines[0] = logLine{}
+	q.lines = q.lines[1:]
+	return l, true
+}
+
+// logLineWriter parses the output of `nerdctl logs -t` into a queue of log lines.
+// A line without a timestamp inherits the timestamp of the previous line.
+type logLineWriter struct {
+	queue   *logQueue
+	service string
+	tagger  *pipetagger.PipeTagger
+	buf     []byte
+	ts      time.Time
+}
+
+func newLogLineWriter(service string, tagger *pipetagger.PipeTagger) *logLineWriter {
+	return &logLineWriter{
+		queue:   newLogQueue(),
+		service: service,
+		tagger:  tagger,
+	}
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.pushLine(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Close pushes the last line if it does not end with a newline, and ends the queue.
+func (w *logLineWriter) Close() error {
+	if len(w.buf) > 0 {
+		w.pushLine(string(w.buf))
+		w.buf = nil
+	}
+	w.queue.close()
+	return nil
+}
+
+func (w *logLineWriter) pushLine(text string) {
+	prefix, rest, _ := strings.Cut(text, " ")
+	if parsed, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+		w.ts, text = parsed, rest
+	}
+	w.queue.push(logLine{timestamp: w.ts, text: text, service: w.service, tagger: w.tagger})
+}
+
+// mergedLogs shows the logs of the containers, merged in timestamp order.
+// The tail is applied to each service, so that the output contains the last lines of the service
+// regardless of the number of its containers.
+func (c *Composer) mergedLogs(ctx context.Context, containerStates map[string]logsContainerState, lo LogsOptions, logWidth int) error {
+	tail := -1
+	if lo.Tail != "" && lo.Tail != "all" {
+		var err error
+		tail, err = strconv.Atoi(lo.Tail)
+		if err != nil {
+			return fmt.Errorf("invalid tail %q: %w", lo.Tail, err)
+		}
+	}
+
+	var queues []*logQueue
+	for id, state := range containerStates {
+		stdout := newLogLineWriter(state.service, pipetagger.New(os.Stdout, nil, state.logTag, logWidth, lo.NoColor))
+		stderr := newLogLineWriter(state.service, pipetagger.New(os.Stderr, nil, state.logTag, logWidth, lo.NoColor))
+		// the timestamps are shown regardless of lo.Timestamps, for sorting the lines
+		opt, err := c.containerLogsOptions(state, lo, true, stdout, stderr)
+		if err != nil {
+			return err
+		}
+		queues = append(queues, stdout.queue, stderr.queue)
+		go func() {
+			if err := nerdctlcontainer.Logs(ctx, c.client, id, opt); err != nil {
+				log.G(ctx).WithError(err).Warnf("failed to get the logs of container %s", state.name)
 			}
+			stdout.Close()
+			stderr.Close()
+		}()
+	}
+
+	mergeLogLines(queues, tail, func(l logLine) {
+		writeLogLine(l, lo)
+	})
+	return nil
+}
+
+// mergeLogLines passes the lines of the queues to write in timestamp order.
+// The lines of each queue are already in timestamp order, so the queues are merged while they
+// are being filled. When tail is not negative, only the last tail lines of each service are written,
+// after all the queues have ended.
+func mergeLogLines(queues []*logQueue, tail int, write func(logLine)) {
+	var (
+		heads        = make([]*logLine, len(queues))
+		serviceLines = make(map[string][]logLine) // key: service name, value: the last lines of the service
+	)
+	next := func(i int) {
+		heads[i] = nil
+		if l, ok := queues[i].pop(); ok {
+			heads[i] = &l
+		}
+	}
+	for i := range queues {
+		next(i)
+	}
+	for {
+		oldest := -1
+		for i, l := range heads {
+			if l != nil && (oldest < 0 || l.timestamp.Before(heads[oldest].timestamp)) {
+				oldest = i
+			}
+		}
+		if oldest < 0 {
+			break
+		}
+		l := *heads[oldest]
+		next(oldest)
+		switch {
+		case tail < 0:
+			write(l)
+		case tail > 0:
+			svcLines := append(serviceLines[l.service], l)
+			if len(svcLines) > tail {
+				svcLines = svcLines[len(svcLines)-tail:]
+			}
+			serviceLines[l.service] = svcLines
 		}
 	}
 
-	return containerError
+	var lines []logLine
+	for _, svcLines := range serviceLines {
+		lines = append(lines, svcLines...)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+	for _, l := range lines {
+		write(l)
+	}
+}
+
+func writeLogLine(l logLine, lo LogsOptions) {
+	if lo.Timestamps {
+		l.tagger.WriteLine(l.timestamp.Format(time.RFC3339Nano) + " " + l.text)
+	} else {
+		l.tagger.WriteLine(l.text)
+	}
 }
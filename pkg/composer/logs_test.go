@@ -0,0 +1,93 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// mergeLogLinesWithTimeout collects the merged lines of the queues, and fails the test when the merge hangs.
+func mergeLogLinesWithTimeout(t *testing.T, queues []*logQueue, tail int) []logLine {
+	t.Helper()
+	var lines []logLine
+	done := make(chan struct{})
+	go func() {
+		mergeLogLines(queues, tail, func(l logLine) {
+			lines = append(lines, l)
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("merging the logs did not complete")
+	}
+	return lines
+}
+
+func TestMergeLogLinesLargeStdoutLateStderr(t *testing.T) {
+	t.Parallel()
+
+	const stdoutLines = 10000 // far more than a pipe buffer
+	padding := strings.Repeat("x", 64)
+	stdout := newLogLineWriter("svc", nil)
+	stderr := newLogLineWriter("svc", nil)
+	// a single writer, like `nerdctl logs`, writes all of its stdout before its first stderr line
+	go func() {
+		for i := 0; i < stdoutLines; i++ {
+			fmt.Fprintf(stdout, "2024-01-01T00:00:00.%09dZ stdout %d %s\n", i, i, padding)
+		}
+		stdout.Close()
+		fmt.Fprintln(stderr, "2024-01-01T00:00:01Z stderr")
+		stderr.Close()
+	}()
+
+	lines := mergeLogLinesWithTimeout(t, []*logQueue{stdout.queue, stderr.queue}, -1)
+
+	assert.Equal(t, len(lines), stdoutLines+1)
+	assert.Equal(t, lines[0].text, "stdout 0 "+padding)
+	assert.Equal(t, lines[len(lines)-1].text, "stderr")
+}
+
+func TestMergeLogLinesTail(t *testing.T) {
+	t.Parallel()
+
+	queue := func(service, logs string) *logQueue {
+		w := newLogLineWriter(service, nil)
+		_, err := io.WriteString(w, logs)
+		assert.NilError(t, err)
+		assert.NilError(t, w.Close())
+		return w.queue
+	}
+	lines := mergeLogLinesWithTimeout(t, []*logQueue{
+		queue("web", "2024-01-01T00:00:01Z web1-a\n2024-01-01T00:00:04Z web1-b\n"),
+		queue("web", "2024-01-01T00:00:02Z web2-a\n2024-01-01T00:00:05Z web2-b\n"),
+		queue("db", "2024-01-01T00:00:03Z db-a\n2024-01-01T00:00:06Z db-b"),
+	}, 2)
+
+	var texts []string
+	for _, l := range lines {
+		texts = append(texts, l.text)
+	}
+	assert.DeepEqual(t, texts, []string{"db-a", "web1-b", "web2-b", "db-b"})
+}
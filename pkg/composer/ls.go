@@ -0,0 +1,124 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// ProjectSummary is a compose project found in the namespace,
+// compatible with the output of `docker compose ls --format json`.
+type ProjectSummary struct {
+	Name        string
+	Status      string // e.g., "running(2), exited(1)"
+	ConfigFiles string // comma-separated
+}
+
+// ListProjects returns the compose projects of the containers in the namespace, sorted by name.
+// Unless all is set, the projects without running containers are omitted.
+// Unlike the other operations, ListProjects does not need a compose file, so it is not a method of Composer.
+func ListProjects(ctx context.Context, client *containerd.Client, all bool) ([]ProjectSummary, error) {
+	containers, err := client.Containers(ctx, fmt.Sprintf("labels.%q", labels.ComposeProject))
+	if err != nil {
+		return nil, err
+	}
+
+	type project struct {
+		states      map[string]int // key: container state
+		configFiles []string
+	}
+	projects := make(map[string]*project)
+	for _, container := range containers {
+		containerLabels, err := container.Labels(ctx)
+		if err != nil {
+			// the container may have been removed meanwhile
+			continue
+		}
+		name := containerLabels[labels.ComposeProject]
+		if name == "" {
+			continue
+		}
+		state, err := containerState(ctx, container)
+		if err != nil {
+			// the container may have been removed meanwhile
+			log.G(ctx).WithError(err).Warnf("failed to get the state of container %s", container.ID())
+			continue
+		}
+		p, ok := projects[name]
+		if !ok {
+			p = &project{states: make(map[string]int)}
+			projects[name] = p
+		}
+		p.states[state]++
+		for _, f := range strings.Split(containerLabels[labels.ComposeConfigFiles], ",") {
+			if f != "" && !slices.Contains(p.configFiles, f) {
+				p.configFiles = append(p.configFiles, f)
+			}
+		}
+	}
+
+	res := []ProjectSummary{}
+	for name, p := range projects {
+		if !all && p.states[string(containerd.Running)] == 0 {
+			continue
+		}
+		states := make([]string, 0, len(p.states))
+		for state := range p.states {
+			states = append(states, state)
+		}
+		sort.Strings(states)
+		status := make([]string, len(states))
+		for i, state := range states {
+			status[i] = fmt.Sprintf("%s(%d)", state, p.states[state])
+		}
+		res = append(res, ProjectSummary{
+			Name:        name,
+			Status:      strings.Join(status, ", "),
+			ConfigFiles: strings.Join(p.configFiles, ","),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// containerState returns the Docker-compatible state of the container, e.g., "running" or "exited".
+func containerState(ctx context.Context, container containerd.Container) (string, error) {
+	status, err := containerutil.ContainerStatus(ctx, container)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return "created", nil
+		}
+		return "", err
+	}
+	if status.Status == containerd.Stopped {
+		return "exited", nil
+	}
+	return string(status.Status), nil
+}
@@ -96,16 +96,20 @@ type PipeTagger struct {
 func (x *PipeTagger) Run() error {
 	scanner := bufio.NewScanner(x.r)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if x.width < 0 {
-			fmt.Fprintln(x.w, line)
-		} else {
-			fmt.Fprintf(x.w, "%s%s|%s\n",
-				x.color.Sprint(x.tag),
-				strings.Repeat(" ", x.width-len(x.tag)),
-				line,
-			)
-		}
+		x.WriteLine(scanner.Text())
 	}
 	return scanner.Err()
 }
+
+// WriteLine writes a line to the writer with the tag.
+func (x *PipeTagger) WriteLine(line string) {
+	if x.width < 0 {
+		fmt.Fprintln(x.w, line)
+	} else {
+		fmt.Fprintf(x.w, "%s%s|%s\n",
+			x.color.Sprint(x.tag),
+			strings.Repeat(" ", x.width-len(x.tag)),
+			line,
+		)
+	}
+}
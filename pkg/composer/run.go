@@ -61,6 +61,10 @@ type RunOptions struct {
 }
 
 func (c *Composer) Run(ctx context.Context, ro RunOptions) error {
+	if err := c.checkIncludeConflicts(ctx); err != nil {
+		return err
+	}
+
 	for shortName := range c.project.Networks {
 		if err := c.upNetwork(ctx, shortName); err != nil {
 			return err
@@ -242,7 +246,11 @@ func (c *Composer) runServices(ctx context.Context, parsedServices []*servicepar
 		container := ps.Containers[0]
 
 		runEG.Go(func() error {
-			id, err := c.upServiceContainer(ctx, ps, container, RecreateForce)
+			existingCid, err := c.containerID(ctx, container.Name, ps.Unparsed.Name)
+			if err != nil {
+				return fmt.Errorf("error while checking for containers with name %q: %w", container.Name, err)
+			}
+			id, err := c.upServiceContainer(ctx, ps, container, existingCid, upContainerOptions{recreate: RecreateForce})
 			if err != nil {
 				return err
 			}
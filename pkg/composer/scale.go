@@ -0,0 +1,140 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// ScaleOptions stores all option input from `nerdctl compose scale`
+type ScaleOptions struct {
+	Scale map[string]int // map of service name to replicas
+}
+
+// Scale sets the number of replicas of the services, without touching the other containers of the project.
+// New replicas are created with the next free suffixes of `serviceparser.DefaultContainerName`,
+// and the replicas with the highest suffixes are removed first.
+func (c *Composer) Scale(ctx context.Context, so ScaleOptions) error {
+	services := make([]string, 0, len(so.Scale))
+	for svc, replicas := range so.Scale {
+		if replicas < 0 {
+			return fmt.Errorf("service %s: invalid replicas: %d", svc, replicas)
+		}
+		services = append(services, svc)
+	}
+	return c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+		replicas := so.Scale[svc.Name]
+		if svc.Deploy == nil {
+			svc.Deploy = &types.DeployConfig{}
+		}
+		svc.Deploy.Replicas = &replicas
+		ps, err := serviceparser.Parse(c.project, *svc)
+		if err != nil {
+			return err
+		}
+		return c.scaleService(ctx, ps)
+	}, types.IgnoreDependencies)
+}
+
+func (c *Composer) scaleService(ctx context.Context, ps *serviceparser.Service) error {
+	containers, err := c.Containers(ctx, ps.Unparsed.Name)
+	if err != nil {
+		return err
+	}
+	type replica struct {
+		index     int
+		container containerd.Container
+	}
+	existing := make(map[string]struct{}) // key: container name
+	var excess []replica
+	for _, container := range containers {
+		containerLabels, err := container.Labels(ctx)
+		if err != nil {
+			return err
+		}
+		name := containerLabels[labels.Name]
+		existing[name] = struct{}{}
+		index := replicaIndex(c.project.Name, ps.Unparsed.Name, name)
+		if name == ps.Unparsed.ContainerName {
+			index = 1
+		}
+		if index > len(ps.Containers) {
+			excess = append(excess, replica{index: index, container: container})
+		}
+	}
+
+	// scale down, starting from the highest-numbered replica
+	sort.Slice(excess, func(i, j int) bool {
+		return excess[i].index > excess[j].index
+	})
+	for _, r := range excess {
+		toRemove := []containerd.Container{r.container}
+		if err := c.stopContainers(ctx, toRemove, StopOptions{}); err != nil {
+			return err
+		}
+		if err := c.removeContainers(ctx, toRemove, RemoveOptions{Stop: true}); err != nil {
+			return err
+		}
+	}
+
+	// scale up
+	var missing []serviceparser.Container
+	for _, container := range ps.Containers {
+		if _, ok := existing[container.Name]; !ok {
+			missing = append(missing, container)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := c.ensureServiceImage(ctx, ps, true, false, BuildOptions{}, false, ""); err != nil {
+		return err
+	}
+	for _, container := range missing {
+		if _, err := c.upServiceContainer(ctx, ps, container, "", upContainerOptions{recreate: RecreateNever}); err != nil {
+			return err
+		}
+	}
+	log.G(ctx).Infof("Service %s scaled to %d replicas", ps.Unparsed.Name, len(ps.Containers))
+	return nil
+}
+
+// replicaIndex returns the suffix number of a container named with `serviceparser.DefaultContainerName`,
+// or 0 if the container is not named after the service (e.g., `container_name` is set).
+func replicaIndex(projectName, serviceName, containerName string) int {
+	suffix, ok := strings.CutPrefix(containerName, serviceparser.DefaultContainerName(projectName, serviceName, ""))
+	if !ok {
+		return 0
+	}
+	index, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0
+	}
+	return index
+}
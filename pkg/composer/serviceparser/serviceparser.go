@@ -19,11 +19,13 @@ package serviceparser
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -70,6 +72,7 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		"DependsOn",
 		"Deploy",
 		"Devices",
+		"Develop",    // handled by `compose watch`
 		"Dockerfile", // handled by the loader (normalizer)
 		"DNS",
 		"DNSSearch",
@@ -79,9 +82,11 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		"Extends", // handled by the loader
 		"Extensions",
 		"ExtraHosts",
+		"HealthCheck",
 		"Hostname",
 		"Image",
 		"Init",
+		"Ipc",
 		"Labels",
 		"Logging",
 		"MemLimit",
@@ -91,6 +96,8 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		"PidsLimit",
 		"Platform",
 		"Ports",
+		"PostStart", // handled by the composer
+		"PreStop",   // handled by the composer
 		"Privileged",
 		"PullPolicy",
 		"ReadOnly",
@@ -122,14 +129,21 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		}
 	}
 
+	implied := impliedDependencies(svc)
 	for depName, dep := range svc.DependsOn {
 		if unknown := reflectutil.UnknownNonEmptyFields(&dep,
 			"Condition",
+			"Required",
+			"Restart",
 		); len(unknown) > 0 {
 			log.L.Warnf("Ignoring: service %s: depends_on: %s: %+v", svc.Name, depName, unknown)
 		}
+		// the loader sets restart for the dependencies implied by links and `service:` references
+		if dep.Restart && !slices.Contains(implied, depName) {
+			log.L.Warnf("Ignoring: service %s: depends_on: %s: restart", svc.Name, depName)
+		}
 		switch dep.Condition {
-		case "", types.ServiceConditionStarted:
+		case "", types.ServiceConditionStarted, types.ServiceConditionHealthy, types.ServiceConditionCompletedSuccessfully:
 			// NOP
 		default:
 			log.L.Warnf("Ignoring: service %s: depends_on: %s: condition %s", svc.Name, depName, dep.Condition)
@@ -147,6 +161,7 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		if svc.Deploy.RestartPolicy != nil {
 			if unknown := reflectutil.UnknownNonEmptyFields(svc.Deploy.RestartPolicy,
 				"Condition",
+				"MaxAttempts",
 			); len(unknown) > 0 {
 				log.L.Warnf("Ignoring: service %s: deploy.restart_policy: %+v", svc.Name, unknown)
 			}
@@ -185,6 +200,20 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		}
 	}
 
+	if svc.HealthCheck != nil {
+		if unknown := reflectutil.UnknownNonEmptyFields(svc.HealthCheck,
+			"Test",
+			"Timeout",
+			"Interval",
+			"Retries",
+			"StartPeriod",
+			"StartInterval",
+			"Disable",
+		); len(unknown) > 0 {
+			log.L.Warnf("Ignoring: service %s: healthcheck: %+v", svc.Name, unknown)
+		}
+	}
+
 	// unknown fields of Build is checked in parseBuild().
 }
 
@@ -192,6 +221,18 @@ type Container struct {
 	Name    string   // e.g., "compose-wordpress_wordpress_1"
 	RunArgs []string // {"--pull=never", ...}
 	Mkdir   []string // For Bind.CreateHostPath
+	Files   []File   // For secrets and configs with UID, GID, Mode, or inline content
+}
+
+// File is a secret or a config that has to be written by the composer, for setting its ownership and mode
+// (as a bind mount cannot change them), or because its content is not sourced from a file.
+type File struct {
+	Source  string      // e.g., "/home/foo/project/secret.txt", empty if Content is set
+	Content []byte      // For `content` and `environment`
+	Target  string      // e.g., "/run/secrets/secret"
+	UID     int         // -1 if not specified
+	GID     int         // -1 if not specified
+	Mode    os.FileMode // e.g., 0o444
 }
 
 type Build struct {
@@ -338,7 +379,11 @@ func getRestart(svc types.ServiceConfig) (string, error) {
 		if svc.Restart != "" {
 			log.L.Warnf("deploy.restart_policy and restart must not be set together, ignoring restart=%s", svc.Restart)
 		}
-		switch cond := svc.Deploy.RestartPolicy.Condition; cond {
+		cond := svc.Deploy.RestartPolicy.Condition
+		if svc.Deploy.RestartPolicy.MaxAttempts != nil && cond != "on-failure" {
+			log.L.Warnf("Ignoring: service %s: deploy.restart_policy.max_attempts (only supported with condition=on-failure)", svc.Name)
+		}
+		switch cond {
 		case "", "any":
 			restartFlag = "always"
 		case "always":
@@ -348,7 +393,10 @@ func getRestart(svc types.ServiceConfig) (string, error) {
 		case "no":
 			return "", fmt.Errorf("deploy.restart_policy.condition: \"no\" is invalid, did you mean \"none\"?")
 		case "on-failure":
-			log.L.Warnf("Ignoring: service %s: deploy.restart_policy.condition=%q (unimplemented)", svc.Name, cond)
+			restartFlag = "on-failure"
+			if maxAttempts := svc.Deploy.RestartPolicy.MaxAttempts; maxAttempts != nil && *maxAttempts > 0 {
+				restartFlag = fmt.Sprintf("on-failure:%d", *maxAttempts)
+			}
 		default:
 			log.L.Warnf("Ignoring: service %s: deploy.restart_policy.condition=%q (unknown)", svc.Name, cond)
 		}
@@ -357,6 +405,124 @@ func getRestart(svc types.ServiceConfig) (string, error) {
 	return restartFlag, nil
 }
 
+// getHealthcheck returns `nerdctl run --health-*` flags
+//
+// healthcheck: https://github.com/compose-spec/compose-spec/blob/master/05-services.md#healthcheck
+func getHealthcheck(svc types.ServiceConfig) ([]string, error) {
+	hc := svc.HealthCheck
+	if hc == nil {
+		return nil, nil
+	}
+	if hc.Disable {
+		if len(hc.Test) > 0 {
+			return nil, fmt.Errorf("service %s: healthcheck.disable and healthcheck.test must not be set together", svc.Name)
+		}
+		return []string{"--no-healthcheck"}, nil
+	}
+
+	var flags []string
+	if len(hc.Test) > 0 {
+		switch hc.Test[0] {
+		case "NONE":
+			if len(hc.Test) > 1 {
+				return nil, fmt.Errorf("service %s: healthcheck.test: \"NONE\" must not be followed by arguments", svc.Name)
+			}
+			return []string{"--no-healthcheck"}, nil
+		case "CMD":
+			if len(hc.Test) < 2 {
+				return nil, fmt.Errorf("service %s: healthcheck.test: \"CMD\" requires a command", svc.Name)
+			}
+			// the exec form is passed as a JSON array, so that the command does not need a shell in the container
+			test, err := json.Marshal([]string(hc.Test))
+			if err != nil {
+				return nil, err
+			}
+			flags = append(flags, "--health-cmd="+string(test))
+		case "CMD-SHELL":
+			if len(hc.Test) < 2 {
+				return nil, fmt.Errorf("service %s: healthcheck.test: \"CMD-SHELL\" requires a command", svc.Name)
+			}
+			flags = append(flags, "--health-cmd="+strings.Join(hc.Test[1:], " "))
+		default:
+			// the string form of test is converted to CMD-SHELL by compose-go, so this should not happen
+			return nil, fmt.Errorf("service %s: healthcheck.test: unknown type %q", svc.Name, hc.Test[0])
+		}
+	}
+	if hc.Interval != nil {
+		flags = append(flags, "--health-interval="+time.Duration(*hc.Interval).String())
+	}
+	if hc.Timeout != nil {
+		flags = append(flags, "--health-timeout="+time.Duration(*hc.Timeout).String())
+	}
+	if hc.Retries != nil {
+		flags = append(flags, fmt.Sprintf("--health-retries=%d", *hc.Retries))
+	}
+	if hc.StartPeriod != nil {
+		flags = append(flags, "--health-start-period="+time.Duration(*hc.StartPeriod).String())
+	}
+	if hc.StartInterval != nil {
+		flags = append(flags, "--health-start-interval="+time.Duration(*hc.StartInterval).String())
+	}
+	return flags, nil
+}
+
+// NamespaceServices returns the names of the services whose namespaces are joined by svc,
+// through `network_mode`, `pid` or `ipc` set to "service:NAME".
+// The containers of svc have to be recreated when the containers of these services are recreated.
+func NamespaceServices(svc types.ServiceConfig) []string {
+	var res []string
+	for _, mode := range []string{svc.NetworkMode, svc.Pid, svc.Ipc} {
+		if name, ok := strings.CutPrefix(mode, types.ServicePrefix); ok && !slices.Contains(res, name) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// impliedDependencies returns the services that the loader adds to `depends_on`
+// for the links and the `service:` namespace references of the service.
+func impliedDependencies(svc types.ServiceConfig) []string {
+	var res []string
+	for _, link := range svc.Links {
+		name, _, _ := strings.Cut(link, ":")
+		res = append(res, name)
+	}
+	for _, mode := range []string{svc.NetworkMode, svc.Ipc, svc.Pid, svc.Uts, svc.Cgroup} {
+		if name, ok := strings.CutPrefix(mode, types.ServicePrefix); ok {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// resolveServiceNamespace converts "service:NAME" into "container:CONTAINER",
+// where CONTAINER is the first container of the service NAME.
+// Other values are returned as is.
+func resolveServiceNamespace(project *types.Project, mode string) (string, error) {
+	name, ok := strings.CutPrefix(mode, types.ServicePrefix)
+	if !ok {
+		return mode, nil
+	}
+	target, err := project.GetService(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid service %q: %w", name, err)
+	}
+	if target.ContainerName != "" {
+		return "container:" + target.ContainerName, nil
+	}
+	return "container:" + DefaultContainerName(project.Name, target.Name, "1"), nil
+}
+
+// ipcShared returns true if the IPC namespace of the service is joined by another service.
+func ipcShared(project *types.Project, name string) bool {
+	for _, svc := range project.Services {
+		if svc.Ipc == types.ServicePrefix+name {
+			return true
+		}
+	}
+	return false
+}
+
 type networkNamePair struct {
 	shortNetworkName string
 	fullName         string
@@ -385,13 +551,17 @@ func getNetworks(project *types.Project, svc types.ServiceConfig) ([]networkName
 		if svc.Net != "" && svc.NetworkMode != svc.Net {
 			return nil, errors.New("net and network_mode must not be set together")
 		}
-		if strings.Contains(svc.NetworkMode, ":") {
-			if !strings.HasPrefix(svc.NetworkMode, "container:") && !strings.HasPrefix(svc.NetworkMode, "ns:") {
-				return nil, fmt.Errorf("unsupported network_mode: %q", svc.NetworkMode)
+		networkMode, err := resolveServiceNamespace(project, svc.NetworkMode)
+		if err != nil {
+			return nil, fmt.Errorf("network_mode: %w", err)
+		}
+		if strings.Contains(networkMode, ":") {
+			if !strings.HasPrefix(networkMode, "container:") && !strings.HasPrefix(networkMode, "ns:") {
+				return nil, fmt.Errorf("unsupported network_mode: %q", networkMode)
 			}
 		}
 		fullNames = append(fullNames, networkNamePair{
-			fullName:         svc.NetworkMode,
+			fullName:         networkMode,
 			shortNetworkName: "",
 		})
 	}
@@ -546,6 +716,12 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 		}
 	}
 
+	if hcFlags, err := getHealthcheck(svc); err != nil {
+		return nil, err
+	} else if len(hcFlags) > 0 {
+		c.RunArgs = append(c.RunArgs, hcFlags...)
+	}
+
 	if svc.Init != nil && *svc.Init {
 		c.RunArgs = append(c.RunArgs, "--init")
 	}
@@ -615,7 +791,22 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 	}
 
 	if svc.Pid != "" {
-		c.RunArgs = append(c.RunArgs, "--pid="+svc.Pid)
+		pid, err := resolveServiceNamespace(project, svc.Pid)
+		if err != nil {
+			return nil, fmt.Errorf("pid: %w", err)
+		}
+		c.RunArgs = append(c.RunArgs, "--pid="+pid)
+	}
+
+	if svc.Ipc != "" {
+		ipc, err := resolveServiceNamespace(project, svc.Ipc)
+		if err != nil {
+			return nil, fmt.Errorf("ipc: %w", err)
+		}
+		c.RunArgs = append(c.RunArgs, "--ipc="+ipc)
+	} else if ipcShared(project, svc.Name) {
+		// the IPC namespace of a container can only be joined when it is shareable
+		c.RunArgs = append(c.RunArgs, "--ipc=shareable")
 	}
 
 	if svc.PidsLimit > 0 {
@@ -705,19 +896,27 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 
 	for _, config := range svc.Configs {
 		fileRef := types.FileReferenceConfig(config)
-		vStr, err := fileReferenceConfigToFlagV(fileRef, project, false)
+		vStr, file, err := fileReferenceConfigToFlagV(fileRef, project, false)
 		if err != nil {
 			return nil, err
 		}
+		if file != nil {
+			c.Files = append(c.Files, *file)
+			continue
+		}
 		c.RunArgs = append(c.RunArgs, "-v="+vStr)
 	}
 
 	for _, secret := range svc.Secrets {
 		fileRef := types.FileReferenceConfig(secret)
-		vStr, err := fileReferenceConfigToFlagV(fileRef, project, true)
+		vStr, file, err := fileReferenceConfigToFlagV(fileRef, project, true)
 		if err != nil {
 			return nil, err
 		}
+		if file != nil {
+			c.Files = append(c.Files, *file)
+			continue
+		}
 		c.RunArgs = append(c.RunArgs, "-v="+vStr)
 	}
 
@@ -847,7 +1046,10 @@ func serviceVolumeConfigToFlagV(c types.ServiceVolumeConfig, project *types.Proj
 	return s, mkdir, nil
 }
 
-func fileReferenceConfigToFlagV(c types.FileReferenceConfig, project *types.Project, secret bool) (string, error) {
+// fileReferenceConfigToFlagV returns the `-v` flag for bind-mounting the secret or the config.
+// When UID, GID or Mode is specified, or when the content is not sourced from a file,
+// a File is returned instead, as the file has to be written by the composer.
+func fileReferenceConfigToFlagV(c types.FileReferenceConfig, project *types.Project, secret bool) (string, *File, error) {
 	objType := "config"
 	if secret {
 		objType = "secret"
@@ -859,28 +1061,44 @@ func fileReferenceConfigToFlagV(c types.FileReferenceConfig, project *types.Proj
 	}
 
 	if err := identifiers.ValidateDockerCompat(c.Source); err != nil {
-		return "", fmt.Errorf("invalid source name for %s: %w", objType, err)
+		return "", nil, fmt.Errorf("invalid source name for %s: %w", objType, err)
 	}
 
 	var obj types.FileObjectConfig
 	if secret {
 		secret, ok := project.Secrets[c.Source]
 		if !ok {
-			return "", fmt.Errorf("secret %s is undefined", c.Source)
+			return "", nil, fmt.Errorf("secret %s is undefined", c.Source)
 		}
 		obj = types.FileObjectConfig(secret)
 	} else {
 		config, ok := project.Configs[c.Source]
 		if !ok {
-			return "", fmt.Errorf("config %s is undefined", c.Source)
+			return "", nil, fmt.Errorf("config %s is undefined", c.Source)
 		}
 		obj = types.FileObjectConfig(config)
 	}
-	src := project.RelativePath(obj.File)
-	var err error
-	src, err = filepath.Abs(src)
-	if err != nil {
-		return "", fmt.Errorf("%s %s: invalid relative path %q: %w", objType, c.Source, src, err)
+	var (
+		src     string
+		content []byte
+		err     error
+	)
+	switch {
+	case obj.Environment != "":
+		v, ok := project.Environment[obj.Environment]
+		if !ok {
+			return "", nil, fmt.Errorf("%s %s: environment variable %q is not set", objType, c.Source, obj.Environment)
+		}
+		content = []byte(v)
+	case obj.File != "":
+		src, err = filepath.Abs(project.RelativePath(obj.File))
+		if err != nil {
+			return "", nil, fmt.Errorf("%s %s: invalid relative path %q: %w", objType, c.Source, obj.File, err)
+		}
+	default:
+		// The model does not tell an empty `content` from a missing one,
+		// so an object without `file` and `environment` is an inline content, which may be empty.
+		content = []byte(obj.Content)
 	}
 
 	target := c.Target
@@ -896,24 +1114,41 @@ func fileReferenceConfigToFlagV(c types.FileReferenceConfig, project *types.Proj
 			if secret {
 				target = filepath.Join("/run/secrets", target)
 			} else {
-				return "", fmt.Errorf("config %s: target %q must be an absolute path", c.Source, c.Target)
+				return "", nil, fmt.Errorf("config %s: target %q must be an absolute path", c.Source, c.Target)
 			}
 		}
 	}
 
-	if c.UID != "" {
-		// Raise an error rather than ignoring the value, for avoiding any security issue
-		return "", fmt.Errorf("%s %s: unsupported field: UID", objType, c.Source)
-	}
-	if c.GID != "" {
-		return "", fmt.Errorf("%s %s: unsupported field: GID", objType, c.Source)
-	}
-	if c.Mode != nil {
-		return "", fmt.Errorf("%s %s: unsupported field: Mode", objType, c.Source)
+	// inline contents have to be written to a file, as well as the files with UID, GID or Mode.
+	if content != nil || c.UID != "" || c.GID != "" || c.Mode != nil {
+		file := &File{
+			Source:  src,
+			Content: content,
+			Target:  target,
+			UID:     -1,
+			GID:     -1,
+			Mode:    0o444,
+		}
+		if c.UID != "" {
+			file.UID, err = strconv.Atoi(c.UID)
+			if err != nil || file.UID < 0 {
+				return "", nil, fmt.Errorf("%s %s: invalid uid %q", objType, c.Source, c.UID)
+			}
+		}
+		if c.GID != "" {
+			file.GID, err = strconv.Atoi(c.GID)
+			if err != nil || file.GID < 0 {
+				return "", nil, fmt.Errorf("%s %s: invalid gid %q", objType, c.Source, c.GID)
+			}
+		}
+		if c.Mode != nil {
+			file.Mode = os.FileMode(*c.Mode)
+		}
+		return "", file, nil
 	}
 
 	s := fmt.Sprintf("%s:%s:ro", src, target)
-	return s, nil
+	return s, nil, nil
 }
 
 // DefaultImageName returns the image name following compose naming logic.
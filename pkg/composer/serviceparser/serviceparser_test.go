@@ -482,6 +482,52 @@ services:
 
 }
 
+func TestParseServiceNamespaces(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  vpn:
+    image: alpine:3.14
+  app:
+    image: alpine:3.14
+    network_mode: service:vpn
+    pid: service:vpn
+    ipc: service:vpn
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	vpnSvc, err := project.GetService("vpn")
+	assert.NilError(t, err)
+
+	vpn, err := Parse(project, vpnSvc)
+	assert.NilError(t, err)
+
+	t.Logf("vpn: %+v", vpn)
+	for _, c := range vpn.Containers {
+		assert.Assert(t, in(c.RunArgs, "--ipc=shareable"))
+	}
+
+	appSvc, err := project.GetService("app")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, NamespaceServices(appSvc), []string{"vpn"})
+
+	app, err := Parse(project, appSvc)
+	assert.NilError(t, err)
+
+	t.Logf("app: %+v", app)
+	target := DefaultContainerName(project.Name, "vpn", "1")
+	for _, c := range app.Containers {
+		assert.Assert(t, in(c.RunArgs, "--net=container:"+target))
+		assert.Assert(t, in(c.RunArgs, "--pid=container:"+target))
+		assert.Assert(t, in(c.RunArgs, "--ipc=container:"+target))
+		assert.Assert(t, !in(c.RunArgs, "--hostname=app"))
+	}
+}
+
 func TestParseConfigs(t *testing.T) {
 	t.Parallel()
 	if runtime.GOOS == "windows" {
@@ -541,6 +587,165 @@ configs:
 	}
 }
 
+func TestParseConfigsOwnership(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("test is not compatible with windows")
+	}
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    secrets:
+    - source: secret1
+      uid: "1000"
+      gid: "1000"
+      mode: 0400
+    - secret2
+    configs:
+    - source: config1
+      target: /mnt/config1-foo
+      mode: 0440
+secrets:
+  secret1:
+    file: ./secret1
+  secret2:
+    file: ./secret2
+configs:
+  config1:
+    file: ./config1
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+
+	t.Logf("foo: %+v", foo)
+	for _, c := range foo.Containers {
+		assert.Assert(t, in(c.RunArgs, fmt.Sprintf("-v=%s:/run/secrets/secret2:ro", filepath.Join(project.WorkingDir, "secret2"))))
+		assert.Assert(t, !in(c.RunArgs, fmt.Sprintf("-v=%s:/run/secrets/secret1:ro", filepath.Join(project.WorkingDir, "secret1"))))
+		assert.DeepEqual(t, c.Files, []File{
+			{
+				Source: filepath.Join(project.WorkingDir, "config1"),
+				Target: "/mnt/config1-foo",
+				UID:    -1,
+				GID:    -1,
+				Mode:   0o440,
+			},
+			{
+				Source: filepath.Join(project.WorkingDir, "secret1"),
+				Target: "/run/secrets/secret1",
+				UID:    1000,
+				GID:    1000,
+				Mode:   0o400,
+			},
+		})
+	}
+}
+
+func TestParseConfigsContent(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    secrets:
+    - secret1
+    configs:
+    - config1
+secrets:
+  secret1:
+    environment: FOO_SECRET
+configs:
+  config1:
+    content: |
+      hello
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), map[string]string{"FOO_SECRET": "secret-value"})
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+
+	t.Logf("foo: %+v", foo)
+	for _, c := range foo.Containers {
+		assert.DeepEqual(t, c.Files, []File{
+			{
+				Content: []byte("hello\n"),
+				Target:  "/config1",
+				UID:     -1,
+				GID:     -1,
+				Mode:    0o444,
+			},
+			{
+				Content: []byte("secret-value"),
+				Target:  "/run/secrets/secret1",
+				UID:     -1,
+				GID:     -1,
+				Mode:    0o444,
+			},
+		})
+	}
+
+	project, err = testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+	fooSvc, err = project.GetService("foo")
+	assert.NilError(t, err)
+	_, err = Parse(project, fooSvc)
+	assert.ErrorContains(t, err, "FOO_SECRET")
+}
+
+func TestParseConfigsEmptyContent(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    configs:
+    - config1
+configs:
+  config1:
+    content: ""
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+
+	for _, c := range foo.Containers {
+		// an empty file, not a bind mount of the project directory
+		assert.DeepEqual(t, c.Files, []File{
+			{
+				Content: []byte{},
+				Target:  "/config1",
+				UID:     -1,
+				GID:     -1,
+				Mode:    0o444,
+			},
+		})
+	}
+}
+
 func TestParseRestartPolicy(t *testing.T) {
 	t.Parallel()
 	const dockerComposeYAML = `
@@ -557,6 +762,17 @@ services:
   unless_stopped:
     image: alpine:3.14
     restart: unless-stopped
+  deploy_onfailure_no_count:
+    image: alpine:3.14
+    deploy:
+      restart_policy:
+        condition: on-failure
+  deploy_onfailure_with_count:
+    image: alpine:3.14
+    deploy:
+      restart_policy:
+        condition: on-failure
+        max_attempts: 5
 `
 	comp := testutil.NewComposeDir(t, dockerComposeYAML)
 	defer comp.CleanUp()
@@ -585,4 +801,82 @@ services:
 
 	c = getContainersFromService("unless_stopped")[0]
 	assert.Assert(t, in(c.RunArgs, "--restart=unless-stopped"))
+
+	c = getContainersFromService("deploy_onfailure_no_count")[0]
+	assert.Assert(t, in(c.RunArgs, "--restart=on-failure"))
+
+	c = getContainersFromService("deploy_onfailure_with_count")[0]
+	assert.Assert(t, in(c.RunArgs, "--restart=on-failure:5"))
+}
+
+func TestParseHealthcheck(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  shell:
+    image: alpine:3.14
+    healthcheck:
+      test: curl -f http://localhost
+      interval: 1m30s
+      timeout: 10s
+      retries: 3
+      start_period: 40s
+      start_interval: 5s
+  exec:
+    image: alpine:3.14
+    healthcheck:
+      test: ["CMD", "pg_isready", "-U", "my user"]
+  none:
+    image: alpine:3.14
+    healthcheck:
+      test: ["NONE"]
+  disabled:
+    image: alpine:3.14
+    healthcheck:
+      disable: true
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	getContainersFromService := func(svcName string) []Container {
+		svcConfig, err := project.GetService(svcName)
+		assert.NilError(t, err)
+		svc, err := Parse(project, svcConfig)
+		assert.NilError(t, err)
+
+		return svc.Containers
+	}
+
+	var c Container
+	c = getContainersFromService("shell")[0]
+	assert.Assert(t, in(c.RunArgs, "--health-cmd=curl -f http://localhost"))
+	assert.Assert(t, in(c.RunArgs, "--health-interval=1m30s"))
+	assert.Assert(t, in(c.RunArgs, "--health-timeout=10s"))
+	assert.Assert(t, in(c.RunArgs, "--health-retries=3"))
+	assert.Assert(t, in(c.RunArgs, "--health-start-period=40s"))
+	assert.Assert(t, in(c.RunArgs, "--health-start-interval=5s"))
+
+	c = getContainersFromService("exec")[0]
+	assert.Assert(t, in(c.RunArgs, `--health-cmd=["CMD","pg_isready","-U","my user"]`))
+
+	c = getContainersFromService("none")[0]
+	assert.Assert(t, in(c.RunArgs, "--no-healthcheck"))
+
+	c = getContainersFromService("disabled")[0]
+	assert.Assert(t, in(c.RunArgs, "--no-healthcheck"))
+}
+
+func TestImpliedDependencies(t *testing.T) {
+	t.Parallel()
+	svc := types.ServiceConfig{
+		Name:        "web",
+		Links:       []string{"db:database", "cache"},
+		NetworkMode: "service:proxy",
+		Pid:         "host",
+		Uts:         "service:uts",
+	}
+	assert.DeepEqual(t, impliedDependencies(svc), []string{"db", "cache", "proxy", "uts"})
 }
@@ -18,7 +18,6 @@ package composer
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -34,8 +33,7 @@ type StopOptions struct {
 	Timeout *uint
 }
 
-// Stop stops containers in `services` without removing them. It calls
-// `nerdctl stop CONTAINER_ID` to do the actual job.
+// Stop stops containers in `services` without removing them.
 func (c *Composer) Stop(ctx context.Context, opt StopOptions, services []string) error {
 	serviceNames, err := c.ServiceNames(services...)
 	if err != nil {
@@ -55,12 +53,6 @@ func (c *Composer) Stop(ctx context.Context, opt StopOptions, services []string)
 }
 
 func (c *Composer) stopContainers(ctx context.Context, containers []containerd.Container, opt StopOptions) error {
-	var timeoutArg string
-	if opt.Timeout != nil {
-		// `nerdctl stop` uses `--time` instead of `--timeout`
-		timeoutArg = fmt.Sprintf("--time=%d", *opt.Timeout)
-	}
-
 	var rmWG sync.WaitGroup
 	for _, container := range containers {
 		container := container
@@ -68,13 +60,9 @@ func (c *Composer) stopContainers(ctx context.Context, containers []containerd.C
 		go func() {
 			defer rmWG.Done()
 			info, _ := container.Info(ctx, containerd.WithoutRefreshedMetadata)
+			c.runPreStopHooks(ctx, container)
 			log.G(ctx).Infof("Stopping container %s", info.Labels[labels.Name])
-			args := []string{"stop"}
-			if opt.Timeout != nil {
-				args = append(args, timeoutArg)
-			}
-			args = append(args, container.ID())
-			if err := c.runNerdctlCmd(ctx, args...); err != nil {
+			if err := c.stopContainer(ctx, container.ID(), opt.Timeout); err != nil {
 				log.G(ctx).Warn(err)
 			}
 		}()
@@ -92,8 +80,11 @@ func (c *Composer) stopContainersFromParsedServices(ctx context.Context, contain
 		rmWG.Add(1)
 		go func() {
 			defer rmWG.Done()
+			if ctr, err := c.client.LoadContainer(ctx, id); err == nil {
+				c.runPreStopHooks(ctx, ctr)
+			}
 			log.G(ctx).Infof("Stopping container %s", container.Name)
-			if err := c.runNerdctlCmd(ctx, "stop", id); err != nil {
+			if err := c.stopContainer(ctx, id, nil); err != nil {
 				log.G(ctx).Warn(err)
 			}
 		}()
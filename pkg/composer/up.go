@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 
@@ -43,6 +45,14 @@ type UpOptions struct {
 	NoRecreate           bool
 	Scale                map[string]int // map of service name to replicas
 	Pull                 string
+	ExitCodeFrom         string // return the exit code of the selected service container (implies AbortOnContainerExit)
+	AttachDependencies   bool   // attach to the logs of the dependencies of the services too
+	NoDeps               bool   // do not bring up the dependencies of the services
+	NoStart              bool   // create the containers without starting them
+	RenewAnonVolumes     bool   // create new anonymous volumes instead of reusing the ones of the previous containers
+	// DependencyTimeout is the maximum duration to wait for a dependency to satisfy its `depends_on` condition.
+	// Defaults to DefaultDependencyTimeout.
+	DependencyTimeout time.Duration
 }
 
 func (opts UpOptions) recreateStrategy() string {
@@ -57,6 +67,10 @@ func (opts UpOptions) recreateStrategy() string {
 }
 
 func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) error {
+	if err := c.checkIncludeConflicts(ctx); err != nil {
+		return err
+	}
+
 	for shortName := range c.project.Networks {
 		if err := c.upNetwork(ctx, shortName); err != nil {
 			return err
@@ -104,6 +118,33 @@ func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) erro
 		return err
 	}
 
+	// with --no-deps, only the specified services are brought up, but their dependencies are not orphans.
+	targetServices := parsedServices
+	if uo.NoDeps && len(services) > 0 {
+		targetServices = slices.DeleteFunc(slices.Clone(parsedServices), func(ps *serviceparser.Service) bool {
+			return !slices.Contains(services, ps.Unparsed.Name)
+		})
+	}
+
+	// when services are specified, attach only to them unless --attach-dependencies is set
+	attachServices := services
+	if len(services) == 0 || uo.AttachDependencies {
+		attachServices = nil
+		for _, ps := range targetServices {
+			attachServices = append(attachServices, ps.Unparsed.Name)
+		}
+	}
+	if uo.ExitCodeFrom != "" {
+		if !slices.ContainsFunc(targetServices, func(ps *serviceparser.Service) bool {
+			return ps.Unparsed.Name == uo.ExitCodeFrom
+		}) {
+			return fmt.Errorf("exit-code-from: no such service: %q", uo.ExitCodeFrom)
+		}
+		if !slices.Contains(attachServices, uo.ExitCodeFrom) {
+			attachServices = append(attachServices, uo.ExitCodeFrom)
+		}
+	}
+
 	// remove orphan containers before the service has be started
 	// FYI: https://github.com/docker/compose/blob/v2.3.4/pkg/compose/create.go#L91-L112
 	orphans, err := c.getOrphanContainers(ctx, parsedServices)
@@ -120,16 +161,23 @@ func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) erro
 		}
 	}
 
-	return c.upServices(ctx, parsedServices, uo)
+	return c.upServices(ctx, targetServices, uo, attachServices)
 }
 
 func validateFileObjectConfig(obj types.FileObjectConfig, shortName, objType string, project *types.Project) error {
-	if unknown := reflectutil.UnknownNonEmptyFields(&obj, "Name", "External", "File"); len(unknown) > 0 {
+	if unknown := reflectutil.UnknownNonEmptyFields(&obj, "Name", "External", "File", "Content", "Environment"); len(unknown) > 0 {
 		log.L.Warnf("Ignoring: %s %s: %+v", objType, shortName, unknown)
 	}
 
+	// The model does not tell an empty `content` from a missing one, so an object without `file` and
+	// `environment` is an inline content, which may be empty.
+	// `content` is not checked against `environment`, as the loader copies the variable of `environment`
+	// to `content` for the configs.
 	if obj.File == "" {
-		return fmt.Errorf("%s %q: lacks file path", objType, shortName)
+		return nil
+	}
+	if obj.Content != "" || obj.Environment != "" {
+		return fmt.Errorf("%s %q: only one of file, content and environment can be set", objType, shortName)
 	}
 	fullPath := project.RelativePath(obj.File)
 	if _, err := os.Stat(fullPath); err != nil {
@@ -0,0 +1,191 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+const (
+	// DefaultDependencyTimeout is the default maximum duration to wait for a dependency
+	// to satisfy its `depends_on` condition (see UpOptions.DependencyTimeout).
+	DefaultDependencyTimeout = 5 * time.Minute
+	// dependencyPollInterval is the interval between two checks of a dependency condition.
+	dependencyPollInterval = time.Second
+)
+
+// waitForDependencies blocks until every dependency of the service satisfies its
+// `depends_on` condition (`service_healthy` or `service_completed_successfully`).
+// `service_started` is already satisfied by the dependency ordering of `up`.
+func (c *Composer) waitForDependencies(ctx context.Context, ps *serviceparser.Service, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultDependencyTimeout
+	}
+	for depName, dep := range ps.Unparsed.DependsOn {
+		switch dep.Condition {
+		case types.ServiceConditionHealthy, types.ServiceConditionCompletedSuccessfully:
+		default:
+			continue
+		}
+		log.G(ctx).Infof("Waiting for service %q to satisfy condition %s (required by service %q)", depName, dep.Condition, ps.Unparsed.Name)
+		if err := c.waitForDependency(ctx, depName, dep.Condition, timeout); err != nil {
+			if !dep.Required {
+				log.G(ctx).Warnf("optional dependency %q of service %q is not satisfied: %v", depName, ps.Unparsed.Name, err)
+				continue
+			}
+			return fmt.Errorf("service %q: dependency %q failed: %w", ps.Unparsed.Name, depName, err)
+		}
+	}
+	return nil
+}
+
+func (c *Composer) waitForDependency(ctx context.Context, depName, condition string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(dependencyPollInterval)
+	defer ticker.Stop()
+	for {
+		satisfied, err := c.dependencySatisfied(ctx, depName, condition)
+		if err != nil {
+			return err
+		}
+		if satisfied {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for condition %s", timeout, condition)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// dependencySatisfied returns true when all the containers of the service satisfy the condition.
+// An error is returned when the condition can never be satisfied anymore.
+func (c *Composer) dependencySatisfied(ctx context.Context, depName, condition string) (bool, error) {
+	containers, err := c.Containers(ctx, depName)
+	if err != nil {
+		return false, err
+	}
+	if len(containers) == 0 {
+		return false, fmt.Errorf("no container found for service %q", depName)
+	}
+	for _, container := range containers {
+		var satisfied bool
+		switch condition {
+		case types.ServiceConditionHealthy:
+			satisfied, err = containerHealthy(ctx, container)
+		case types.ServiceConditionCompletedSuccessfully:
+			satisfied, err = containerCompletedSuccessfully(ctx, container)
+		default:
+			return false, fmt.Errorf("unsupported depends_on condition %q", condition)
+		}
+		if err != nil || !satisfied {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// containerHealthy reads the health state recorded by pkg/healthcheck in the container labels.
+func containerHealthy(ctx context.Context, container containerd.Container) (bool, error) {
+	containerLabels, err := container.Labels(ctx)
+	if err != nil {
+		return false, err
+	}
+	name := containerLabels[labels.Name]
+	hcJSON := containerLabels[labels.HealthCheck]
+	if hcJSON == "" {
+		return false, fmt.Errorf("container %s has no healthcheck configured", name)
+	}
+	hc, err := healthcheck.HealthCheckFromJSON(hcJSON)
+	if err != nil {
+		return false, fmt.Errorf("container %s has an invalid healthcheck: %w", name, err)
+	}
+	if len(hc.Test) == 0 || hc.Test[0] == healthcheck.CmdNone {
+		return false, fmt.Errorf("container %s has its healthcheck disabled", name)
+	}
+
+	status, err := containerutil.ContainerStatus(ctx, container)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			// task not created yet
+			return false, nil
+		}
+		return false, err
+	}
+	if status.Status == containerd.Stopped {
+		return false, fmt.Errorf("container %s exited (code %d) before becoming healthy", name, status.ExitStatus)
+	}
+
+	stateJSON := containerLabels[labels.HealthState]
+	if stateJSON == "" {
+		return false, nil
+	}
+	state, err := healthcheck.HealthStateFromJSON(stateJSON)
+	if err != nil {
+		return false, fmt.Errorf("container %s has an invalid health state: %w", name, err)
+	}
+	switch state.Status {
+	case healthcheck.Healthy:
+		return true, nil
+	case healthcheck.Unhealthy:
+		return false, fmt.Errorf("container %s is unhealthy", name)
+	default:
+		return false, nil
+	}
+}
+
+func containerCompletedSuccessfully(ctx context.Context, container containerd.Container) (bool, error) {
+	status, err := containerutil.ContainerStatus(ctx, container)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			// task not created yet
+			return false, nil
+		}
+		return false, err
+	}
+	if status.Status != containerd.Stopped {
+		return false, nil
+	}
+	if status.ExitStatus != 0 {
+		containerLabels, err := container.Labels(ctx)
+		if err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("container %s didn't complete successfully: exit %d", containerLabels[labels.Name], status.ExitStatus)
+	}
+	return true, nil
+}
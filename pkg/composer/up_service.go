@@ -21,21 +21,24 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
 
+	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
-	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
+	"github.com/containerd/nerdctl/v2/pkg/errutil"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
-func (c *Composer) upServices(ctx context.Context, parsedServices []*serviceparser.Service, uo UpOptions) error {
+// upServices creates and starts the containers of parsedServices, and then, unless uo.Detach is set,
+// attaches to the logs of attachServices until the containers exit.
+func (c *Composer) upServices(ctx context.Context, parsedServices []*serviceparser.Service, uo UpOptions, attachServices []string) error {
 	if len(parsedServices) == 0 {
 		return errors.New("no service was provided")
 	}
@@ -51,38 +54,48 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 
 	var (
 		containers   = make(map[string]serviceparser.Container) // key: container ID
-		services     = []string{}
+		recreated    = make(map[string]bool)                    // key: service name
 		containersMu sync.Mutex
 	)
+	// services are brought up concurrently; a service waits until the services it depends on are up.
+	done := make(map[string]chan struct{}, len(parsedServices)) // key: service name
+	for _, ps := range parsedServices {
+		done[ps.Unparsed.Name] = make(chan struct{})
+	}
+	upEG, upCtx := errgroup.WithContext(ctx)
 	for _, ps := range parsedServices {
 		ps := ps
-		var runEG errgroup.Group
-		services = append(services, ps.Unparsed.Name)
-		for _, container := range ps.Containers {
-			container := container
-			runEG.Go(func() error {
-				id, err := c.upServiceContainer(ctx, ps, container, recreate)
-				if err != nil {
-					return err
+		upEG.Go(func() error {
+			for dep := range ps.Unparsed.DependsOn {
+				depDone, ok := done[dep]
+				if !ok {
+					continue
 				}
-				containersMu.Lock()
-				containers[id] = container
-				containersMu.Unlock()
-				return nil
-			})
-		}
-		if err := runEG.Wait(); err != nil {
-			return err
-		}
+				select {
+				case <-depDone:
+				case <-upCtx.Done():
+					return upCtx.Err()
+				}
+			}
+			if err := c.upService(upCtx, ps, uo, containers, recreated, &containersMu); err != nil {
+				return err
+			}
+			close(done[ps.Unparsed.Name])
+			return nil
+		})
+	}
+	if err := upEG.Wait(); err != nil {
+		return err
 	}
 
-	if uo.Detach {
+	if uo.Detach || uo.NoStart {
 		return nil
 	}
 
 	// this is used to stop containers in case --abort-on-container-exit flag is set.
 	// c.Logs returns an error, so we don't need Ctrl-c to reach the "Stopping containers (forcibly)"
-	if uo.AbortOnContainerExit {
+	// With --exit-code-from, the containers are stopped below before collecting the exit code.
+	if uo.AbortOnContainerExit && uo.ExitCodeFrom == "" {
 		defer c.stopContainersFromParsedServices(ctx, containers)
 	}
 	log.G(ctx).Info("Attaching to logs")
@@ -93,8 +106,17 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 		NoLogPrefix:          uo.NoLogPrefix,
 		LatestRun:            recreate == RecreateNever,
 	}
-	if err := c.Logs(ctx, lo, services); err != nil {
-		return err
+	logsErr := c.Logs(ctx, lo, attachServices)
+	if uo.ExitCodeFrom != "" {
+		if logsErr != nil {
+			log.G(ctx).Info(logsErr)
+		}
+		log.G(ctx).Infof("Stopping containers (forcibly)")
+		c.stopContainersFromParsedServices(ctx, containers)
+		return c.serviceExitCode(ctx, uo.ExitCodeFrom, parsedServices, containers)
+	}
+	if logsErr != nil {
+		return logsErr
 	}
 
 	log.G(ctx).Infof("Stopping containers (forcibly)") // TODO: support gracefully stopping
@@ -102,6 +124,90 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 	return nil
 }
 
+// upService creates and starts the containers of the service, once its dependencies satisfy their conditions.
+// The IDs of the containers are added to containers, and the service is added to recreated
+// if any of its containers was (re)created.
+func (c *Composer) upService(ctx context.Context, ps *serviceparser.Service, uo UpOptions,
+	containers map[string]serviceparser.Container, recreated map[string]bool, mu *sync.Mutex) error {
+	// the dependencies are not waited for when they are not brought up, or when nothing is started.
+	if !uo.NoDeps && !uo.NoStart {
+		if err := c.waitForDependencies(ctx, ps, uo.DependencyTimeout); err != nil {
+			return err
+		}
+	}
+	opts := upContainerOptions{
+		recreate:         uo.recreateStrategy(),
+		noStart:          uo.NoStart,
+		renewAnonVolumes: uo.RenewAnonVolumes,
+	}
+	// the containers joining the namespaces of a (re)created container refer to the old namespaces,
+	// so they have to be recreated as well.
+	mu.Lock()
+	for _, dep := range serviceparser.NamespaceServices(*ps.Unparsed) {
+		if recreated[dep] {
+			log.G(ctx).Infof("Service %s joins the namespaces of the re-created service %s, re-creating", ps.Unparsed.Name, dep)
+			opts.recreate = RecreateForce
+		}
+	}
+	mu.Unlock()
+	var runEG errgroup.Group
+	for _, container := range ps.Containers {
+		container := container
+		runEG.Go(func() error {
+			existingCid, err := c.containerID(ctx, container.Name, ps.Unparsed.Name)
+			if err != nil {
+				return fmt.Errorf("error while checking for containers with name %q: %w", container.Name, err)
+			}
+			id, err := c.upServiceContainer(ctx, ps, container, existingCid, opts)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			containers[id] = container
+			if id != existingCid {
+				recreated[ps.Unparsed.Name] = true
+			}
+			mu.Unlock()
+			return nil
+		})
+	}
+	return runEG.Wait()
+}
+
+// serviceExitCode returns an errutil.ExitCoder carrying the exit code of the first container of the service,
+// or nil if it exited successfully.
+// The error is returned unwrapped so that the exit code can be propagated to the CLI.
+func (c *Composer) serviceExitCode(ctx context.Context, service string, parsedServices []*serviceparser.Service, containers map[string]serviceparser.Container) error {
+	idx := slices.IndexFunc(parsedServices, func(ps *serviceparser.Service) bool {
+		return ps.Unparsed.Name == service
+	})
+	if idx < 0 || len(parsedServices[idx].Containers) == 0 {
+		return fmt.Errorf("no container found for service %q", service)
+	}
+	name := parsedServices[idx].Containers[0].Name
+	for id, container := range containers {
+		if container.Name != name {
+			continue
+		}
+		ctr, err := c.client.LoadContainer(ctx, id)
+		if err != nil {
+			return err
+		}
+		status, err := containerutil.ContainerStatus(ctx, ctr)
+		if err != nil {
+			return err
+		}
+		if status.Status != containerd.Stopped {
+			return fmt.Errorf("container %s has not exited (status %s)", name, status.Status)
+		}
+		if status.ExitStatus != 0 {
+			return errutil.NewExitCoderErr(int(status.ExitStatus))
+		}
+		return nil
+	}
+	return fmt.Errorf("no container found for service %q", service)
+}
+
 func (c *Composer) ensureServiceImage(ctx context.Context, ps *serviceparser.Service, allowBuild, forceBuild bool, bo BuildOptions, quiet bool, pullModeArg string) error {
 	if ps.Build != nil && allowBuild {
 		if ps.Build.Force || forceBuild {
@@ -124,39 +230,45 @@ func (c *Composer) ensureServiceImage(ctx context.Context, ps *serviceparser.Ser
 	return c.EnsureImage(ctx, ps.Image, ps.PullMode, ps.Unparsed.Platform, ps, quiet)
 }
 
+// upContainerOptions specifies how upServiceContainer brings up a container.
+type upContainerOptions struct {
+	recreate string
+	// noStart creates the container without starting it.
+	noStart bool
+	// renewAnonVolumes creates new anonymous volumes for a re-created container,
+	// instead of mounting the anonymous volumes of the previous container.
+	renewAnonVolumes bool
+}
+
 // upServiceContainer must be called after ensureServiceImage
 // upServiceContainer returns container ID
-func (c *Composer) upServiceContainer(ctx context.Context, service *serviceparser.Service, container serviceparser.Container, recreate string) (string, error) {
-	// check if container already exists
-	existingCid, err := c.containerID(ctx, container.Name, service.Unparsed.Name)
-	if err != nil {
-		return "", fmt.Errorf("error while checking for containers with name %q: %w", container.Name, err)
-	}
-
+// existingCid is the ID of the existing container with the same name, or empty if there is none (see containerID).
+func (c *Composer) upServiceContainer(ctx context.Context, service *serviceparser.Service, container serviceparser.Container, existingCid string, opts upContainerOptions) (string, error) {
+	var err error
 	// FIXME
 	if service.Unparsed.StdinOpen != service.Unparsed.Tty {
 		return "", fmt.Errorf("currently StdinOpen(-i) and Tty(-t) should be same")
 	}
 
-	var runFlagD bool
-	if !service.Unparsed.StdinOpen && !service.Unparsed.Tty {
-		container.RunArgs = append([]string{"-d"}, container.RunArgs...)
-		runFlagD = true
-	}
+	// interactive containers are attached to the terminal until they exit.
+	interactive := service.Unparsed.StdinOpen && service.Unparsed.Tty && !opts.noStart
 
 	// start the existing container and exit early
-	if existingCid != "" && recreate == RecreateNever {
-		cmd := c.createNerdctlCmd(ctx, append([]string{"start"}, existingCid)...)
-		if err := c.executeUpCmd(ctx, cmd, container.Name, runFlagD, service.Unparsed.StdinOpen); err != nil {
-			return "", fmt.Errorf("error while starting existing container %s: %w", container.Name, err)
+	if existingCid != "" && opts.recreate == RecreateNever {
+		if opts.noStart {
+			return existingCid, nil
+		}
+		if err := c.startExistingServiceContainer(ctx, service, existingCid, container.Name, interactive); err != nil {
+			return "", err
 		}
 		return existingCid, nil
 	}
 
 	// delete container if it already exists
+	var anonVolumes map[string]string
 	if existingCid != "" {
 		// Default behavior for RecreateDiverged: compare stored hash with current service hash
-		if recreate == RecreateDiverged {
+		if opts.recreate == RecreateDiverged {
 			currentHash, err := ServiceHash(*service.Unparsed)
 			if err != nil {
 				return "", fmt.Errorf("failed computing service hash for %s: %w", container.Name, err)
@@ -170,16 +282,23 @@ func (c *Composer) upServiceContainer(ctx context.Context, service *serviceparse
 				return "", fmt.Errorf("failed to read labels for %s: %w", existingCid, err)
 			}
 			if lbls[labels.ComposeConfigHash] == currentHash {
-				cmd := c.createNerdctlCmd(ctx, append([]string{"start"}, existingCid)...)
-				if err := c.executeUpCmd(ctx, cmd, container.Name, runFlagD, service.Unparsed.StdinOpen); err != nil {
-					return "", fmt.Errorf("error while starting existing container %s: %w", container.Name, err)
+				if opts.noStart {
+					return existingCid, nil
+				}
+				if err := c.startExistingServiceContainer(ctx, service, existingCid, container.Name, interactive); err != nil {
+					return "", err
 				}
 				return existingCid, nil
 			}
 		}
+		if !opts.renewAnonVolumes {
+			anonVolumes, err = c.anonymousVolumes(ctx, existingCid)
+			if err != nil {
+				return "", fmt.Errorf("failed to get the anonymous volumes of container %s: %w", container.Name, err)
+			}
+		}
 		log.G(ctx).Debugf("Container %q already exists, deleting", container.Name)
-		delCmd := c.createNerdctlCmd(ctx, "rm", "-f", container.Name)
-		if err = delCmd.Run(); err != nil {
+		if err := c.removeContainer(ctx, existingCid, opts.renewAnonVolumes); err != nil {
 			return "", fmt.Errorf("could not delete container %q: %w", container.Name, err)
 		}
 		log.G(ctx).Infof("Re-creating container %s", container.Name)
@@ -194,63 +313,74 @@ func (c *Composer) upServiceContainer(ctx context.Context, service *serviceparse
 		}
 	}
 
-	tempDir, err := os.MkdirTemp(os.TempDir(), "compose-")
-	if err != nil {
-		return "", fmt.Errorf("error while creating/re-creating container %s: %w", container.Name, err)
-	}
-	defer os.RemoveAll(tempDir)
-	cidFilename := filepath.Join(tempDir, "cid")
-
 	if c.EnvFile != "" {
 		container.RunArgs = append([]string{"--env-file=" + c.EnvFile}, container.RunArgs...)
 	}
 
+	fileFlags, err := c.prepareContainerFiles(ctx, container)
+	if err != nil {
+		return "", err
+	}
+	container.RunArgs = append(fileFlags, container.RunArgs...)
+	container.RunArgs = withAnonymousVolumes(container.RunArgs, anonVolumes)
+
 	//add metadata labels to container https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels
 	currentHash, err := ServiceHash(*service.Unparsed)
 	if err != nil {
 		return "", fmt.Errorf("failed computing service hash for %s: %w", container.Name, err)
 	}
 	container.RunArgs = append([]string{
-		"--cidfile=" + cidFilename,
 		fmt.Sprintf("-l=%s=%s", labels.ComposeProject, c.project.Name),
 		fmt.Sprintf("-l=%s=%s", labels.ComposeService, service.Unparsed.Name),
 		fmt.Sprintf("-l=%s=%s", labels.ComposeConfigHash, currentHash),
+		fmt.Sprintf("-l=%s=%s", labels.ComposeConfigFiles, strings.Join(c.project.ComposeFiles, ",")),
 	}, container.RunArgs...)
 
-	cmd := c.createNerdctlCmd(ctx, append([]string{"run"}, container.RunArgs...)...)
-	if c.DebugPrintFull {
-		log.G(ctx).Debugf("Running %v", cmd.Args)
-	}
-
-	if err := c.executeUpCmd(ctx, cmd, container.Name, runFlagD, service.Unparsed.StdinOpen); err != nil {
-		return "", fmt.Errorf("error while creating container %s: %w", container.Name, err)
-	}
-
-	cid, err := filesystem.ReadFile(cidFilename)
+	cid, err := c.createContainer(ctx, container.RunArgs)
 	if err != nil {
 		return "", fmt.Errorf("error while creating container %s: %w", container.Name, err)
 	}
-	return strings.TrimSpace(string(cid)), nil
+	if err := c.keepAnonymousVolumes(ctx, cid, anonVolumes); err != nil {
+		return "", err
+	}
+	if opts.noStart {
+		return cid, nil
+	}
+	if interactive {
+		if err := c.startContainerWithAttach(ctx, cid, true); err != nil {
+			return "", fmt.Errorf("error while running container %s: %w", container.Name, err)
+		}
+		return cid, nil
+	}
+	if err := c.startContainer(ctx, cid); err != nil {
+		return "", fmt.Errorf("error while starting container %s: %w", container.Name, err)
+	}
+	if err := c.runHooks(ctx, cid, service.Unparsed.PostStart, "post_start"); err != nil {
+		return "", fmt.Errorf("container %s: %w", container.Name, err)
+	}
+	return cid, nil
 }
 
-func (c *Composer) executeUpCmd(ctx context.Context, cmd *exec.Cmd, containerName string, runFlagD, stdinOpen bool) error {
-	log.G(ctx).Infof("Running %v", cmd.Args)
-	if c.DebugPrintFull {
-		log.G(ctx).Debugf("Running %v", cmd.Args)
+func (c *Composer) startExistingServiceContainer(ctx context.Context, service *serviceparser.Service, id, containerName string, interactive bool) error {
+	if interactive {
+		if err := c.startContainerWithAttach(ctx, id, true); err != nil {
+			return fmt.Errorf("error while starting existing container %s: %w", containerName, err)
+		}
+		return nil
 	}
-
-	if stdinOpen {
-		cmd.Stdin = os.Stdin
+	ctr, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load container %s: %w", id, err)
 	}
-	if !runFlagD {
-		cmd.Stdout = os.Stdout
+	// the post_start hooks are not run again for a running container
+	if status, err := containerutil.ContainerStatus(ctx, ctr); err == nil && status.Status == containerd.Running {
+		return nil
 	}
-	// Always propagate stderr to print detailed error messages (https://github.com/containerd/nerdctl/issues/1942)
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error while creating container %s: %w", containerName, err)
+	if err := c.startContainer(ctx, id); err != nil {
+		return fmt.Errorf("error while starting existing container %s: %w", containerName, err)
+	}
+	if err := c.runHooks(ctx, id, service.Unparsed.PostStart, "post_start"); err != nil {
+		return fmt.Errorf("container %s: %w", containerName, err)
 	}
-
 	return nil
 }
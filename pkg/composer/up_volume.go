@@ -18,10 +18,13 @@ package composer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/containerd/log"
 
+	"github.com/containerd/nerdctl/v2/pkg/containerutil"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 	"github.com/containerd/nerdctl/v2/pkg/reflectutil"
 )
@@ -62,3 +65,93 @@ func (c *Composer) upVolume(ctx context.Context, shortName string) error {
 	}
 	return nil
 }
+
+// anonymousVolumes returns the anonymous volumes of the container, keyed by their destination.
+func (c *Composer) anonymousVolumes(ctx context.Context, id string) (map[string]string, error) {
+	container, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	containerLabels, err := container.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	anonLabel, ok := containerLabels[labels.AnonymousVolumes]
+	if !ok {
+		return nil, nil
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(anonLabel), &names); err != nil {
+		return nil, err
+	}
+	anonymous := make(map[string]bool, len(names))
+	for _, name := range names {
+		anonymous[name] = true
+	}
+	volumes := make(map[string]string)
+	for _, vol := range containerutil.GetContainerVolumes(containerLabels) {
+		if vol.Destination != "" && anonymous[vol.Name] {
+			volumes[vol.Destination] = vol.Name
+		}
+	}
+	return volumes, nil
+}
+
+// withAnonymousVolumes rewrites the anonymous volume flags (`-v=TARGET[:MODE]`) of runArgs to mount
+// the existing volumes, keyed by their destination, so that a re-created container keeps the data
+// of the previous one, as `docker compose up` does.
+// The volumes that are not in runArgs (e.g., the ones declared by `VOLUME` in the image) are mounted as well.
+func withAnonymousVolumes(runArgs []string, volumes map[string]string) []string {
+	if len(volumes) == 0 {
+		return runArgs
+	}
+	mounted := make(map[string]bool)
+	res := make([]string, 0, len(runArgs)+len(volumes))
+	for _, arg := range runArgs {
+		if v, ok := strings.CutPrefix(arg, "-v="); ok {
+			target, mode, _ := strings.Cut(v, ":")
+			if name, ok := volumes[target]; ok && (mode == "" || mode == "ro" || mode == "rw") {
+				arg = fmt.Sprintf("-v=%s:%s", name, v)
+				mounted[target] = true
+			}
+		}
+		res = append(res, arg)
+	}
+	for target, name := range volumes {
+		if !mounted[target] {
+			res = append(res, fmt.Sprintf("-v=%s:%s", name, target))
+		}
+	}
+	return res
+}
+
+// keepAnonymousVolumes records the anonymous volumes of the previous container, mounted by withAnonymousVolumes,
+// as anonymous volumes of the re-created container, so that they are removed along with it by `rm -v`.
+func (c *Composer) keepAnonymousVolumes(ctx context.Context, id string, volumes map[string]string) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+	container, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	containerLabels, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	var names []string
+	if anonLabel, ok := containerLabels[labels.AnonymousVolumes]; ok {
+		if err := json.Unmarshal([]byte(anonLabel), &names); err != nil {
+			return err
+		}
+	}
+	for _, name := range volumes {
+		names = append(names, name)
+	}
+	anonJSON, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+	_, err = container.SetLabels(ctx, map[string]string{labels.AnonymousVolumes: string(anonJSON)})
+	return err
+}
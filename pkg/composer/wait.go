@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	nerdctlcontainer "github.com/containerd/nerdctl/v2/pkg/cmd/container"
+)
+
+// WaitOptions stores options passed from users as flags and args.
+type WaitOptions struct {
+	Services []string
+	// DownProject tears down the project once the containers have stopped.
+	DownProject bool
+	Stdout      io.Writer
+}
+
+// Wait blocks until the containers of the services stop, and prints their exit codes, as `nerdctl wait` does.
+func (c *Composer) Wait(ctx context.Context, wo WaitOptions) error {
+	// Wait may block for a long time and should not prevent other compose commands from running meanwhile.
+	if err := Unlock(); err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, service := range wo.Services {
+		containers, err := c.Containers(ctx, service)
+		if err != nil {
+			return fmt.Errorf("fail to get containers for service %s: %w", service, err)
+		}
+		sortContainersByIndex(ctx, containers)
+		for _, container := range containers {
+			ids = append(ids, container.ID())
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no containers from services %v", wo.Services)
+	}
+
+	waitErr := nerdctlcontainer.Wait(ctx, c.client, ids, types.ContainerWaitOptions{
+		Stdout:   wo.Stdout,
+		GOptions: c.globalOptions(),
+	})
+	if !wo.DownProject {
+		return waitErr
+	}
+	if err := Lock(c.config.DataRoot, c.config.Address); err != nil {
+		return errors.Join(waitErr, err)
+	}
+	return errors.Join(waitErr, c.Down(ctx, DownOptions{}))
+}
@@ -0,0 +1,313 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+)
+
+// watchDebounce is the quiet period after the last file event before the triggers are run,
+// so that a burst of writes (e.g., from an editor or `git checkout`) results in a single action.
+const watchDebounce = 500 * time.Millisecond
+
+// WatchOptions stores all option input from `nerdctl compose watch`
+type WatchOptions struct {
+	NoUp  bool // do not build and start the services before watching
+	Quiet bool // hide the build output
+}
+
+type watchTrigger struct {
+	service string
+	types.Trigger
+}
+
+// Watch watches the paths listed in the `develop.watch` section of the services,
+// and syncs the changed files into the containers, or rebuilds and recreates the
+// containers, according to the action of the matching trigger.
+func (c *Composer) Watch(ctx context.Context, wo WatchOptions, services []string) error {
+	var triggers []watchTrigger
+	err := c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+		if svc.Develop == nil {
+			return nil
+		}
+		for _, trigger := range svc.Develop.Watch {
+			switch trigger.Action {
+			case types.WatchActionSync, types.WatchActionSyncRestart:
+				if trigger.Target == "" {
+					return fmt.Errorf("service %s: develop.watch: action %s requires a target", svc.Name, trigger.Action)
+				}
+			case types.WatchActionRebuild:
+				if svc.Build == nil {
+					return fmt.Errorf("service %s: develop.watch: action %s requires a build section", svc.Name, trigger.Action)
+				}
+			default:
+				return fmt.Errorf("service %s: develop.watch: unsupported action %q", svc.Name, trigger.Action)
+			}
+			if trigger.Path == "" {
+				return fmt.Errorf("service %s: develop.watch: path is missing", svc.Name)
+			}
+			if !filepath.IsAbs(trigger.Path) {
+				trigger.Path = c.project.RelativePath(trigger.Path)
+			}
+			triggers = append(triggers, watchTrigger{service: svc.Name, Trigger: trigger})
+		}
+		return nil
+	}, types.IgnoreDependencies)
+	if err != nil {
+		return err
+	}
+	if len(triggers) == 0 {
+		return errors.New("none of the selected services is configured for watch, consider setting a 'develop' section")
+	}
+
+	if !wo.NoUp {
+		if err := c.Up(ctx, UpOptions{Detach: true, QuietPull: wo.Quiet}, services); err != nil {
+			return err
+		}
+	}
+
+	// Watching is a long-running operation: release the lock so that other compose commands can be run meanwhile.
+	// The lock is taken again while the triggers are run.
+	if err := Unlock(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	defer watcher.Close()
+	for _, trigger := range triggers {
+		if err := addWatchPath(watcher, trigger.Path); err != nil {
+			return err
+		}
+		log.G(ctx).Infof("Watching %s for service %s (action: %s)", trigger.Path, trigger.service, trigger.Action)
+	}
+
+	interruptChan := make(chan os.Signal, 1)
+	signal.Notify(interruptChan, os.Interrupt)
+	defer signal.Stop(interruptChan)
+
+	// key: index of the trigger, value: set of changed host paths
+	pending := make(map[int]map[string]struct{})
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sig := <-interruptChan:
+			log.G(ctx).Debugf("Received signal: %s", sig)
+			return nil
+		case err := <-watcher.Errors:
+			log.G(ctx).WithError(err).Warn("Received fsnotify watch error")
+		case e := <-watcher.Events:
+			if e.Has(fsnotify.Chmod) && !e.Has(fsnotify.Write) {
+				continue
+			}
+			// newly created directories need to be watched as well, as fsnotify is not recursive
+			if e.Has(fsnotify.Create) {
+				if st, err := os.Stat(e.Name); err == nil && st.IsDir() {
+					if err := addWatchPath(watcher, e.Name); err != nil {
+						log.G(ctx).WithError(err).Warnf("failed to watch %s", e.Name)
+					}
+				}
+			}
+			for i, trigger := range triggers {
+				if !triggerMatches(trigger.Trigger, e.Name) {
+					continue
+				}
+				if pending[i] == nil {
+					pending[i] = make(map[string]struct{})
+				}
+				pending[i][e.Name] = struct{}{}
+			}
+			if len(pending) > 0 {
+				debounce.Reset(watchDebounce)
+			}
+		case <-debounce.C:
+			for i, paths := range pending {
+				if err := c.runWatchTriggerLocked(ctx, triggers[i], paths, wo); err != nil {
+					log.G(ctx).WithError(err).Errorf("failed to run watch action %s for service %s", triggers[i].Action, triggers[i].service)
+				}
+			}
+			pending = make(map[int]map[string]struct{})
+		}
+	}
+}
+
+// addWatchPath adds p and all the directories under p to the watcher.
+// Files are watched through their parent directory, so that atomic replacements by editors are not missed.
+func addWatchPath(watcher *fsnotify.Watcher, p string) error {
+	st, err := os.Stat(p)
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", p, err)
+	}
+	if !st.IsDir() {
+		return watcher.Add(filepath.Dir(p))
+	}
+	return filepath.WalkDir(p, func(walkPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(walkPath); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", walkPath, err)
+		}
+		return nil
+	})
+}
+
+// triggerMatches returns true if the host path is under the trigger path and not ignored.
+// Ignore patterns are matched against the path relative to the trigger path, and against each of its elements.
+func triggerMatches(trigger types.Trigger, hostPath string) bool {
+	rel, err := filepath.Rel(trigger.Path, hostPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range trigger.Ignore {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if ok, _ := path.Match(pattern, rel); ok {
+			return false
+		}
+		for _, elem := range strings.Split(rel, "/") {
+			if ok, _ := path.Match(pattern, elem); ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// runWatchTriggerLocked runs the trigger while holding the compose lock,
+// so that it does not race with other compose commands run on the project meanwhile.
+func (c *Composer) runWatchTriggerLocked(ctx context.Context, trigger watchTrigger, paths map[string]struct{}, wo WatchOptions) error {
+	if err := Relock(); err != nil {
+		return err
+	}
+	defer Unlock()
+	return c.runWatchTrigger(ctx, trigger, paths, wo)
+}
+
+func (c *Composer) runWatchTrigger(ctx context.Context, trigger watchTrigger, paths map[string]struct{}, wo WatchOptions) error {
+	switch trigger.Action {
+	case types.WatchActionSync, types.WatchActionSyncRestart:
+		for hostPath := range paths {
+			if err := c.syncWatchPath(ctx, trigger, hostPath); err != nil {
+				return err
+			}
+		}
+		if trigger.Action == types.WatchActionSyncRestart {
+			log.G(ctx).Infof("Restarting service %s after sync", trigger.service)
+			return c.Restart(ctx, RestartOptions{}, []string{trigger.service})
+		}
+		return nil
+	case types.WatchActionRebuild:
+		log.G(ctx).Infof("Rebuilding service %s", trigger.service)
+		if err := c.Build(ctx, BuildOptions{Quiet: wo.Quiet}, []string{trigger.service}); err != nil {
+			return err
+		}
+		return c.recreateService(ctx, trigger.service)
+	default:
+		return fmt.Errorf("unsupported action %q", trigger.Action)
+	}
+}
+
+// syncWatchPath copies the host path into the containers of the service,
+// or removes it from the containers if it no longer exists on the host.
+func (c *Composer) syncWatchPath(ctx context.Context, trigger watchTrigger, hostPath string) error {
+	containerPath, err := watchTargetPath(trigger.Trigger, hostPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(hostPath); errors.Is(err, os.ErrNotExist) {
+		containers, err := c.Containers(ctx, trigger.service)
+		if err != nil {
+			return err
+		}
+		for _, container := range containers {
+			log.G(ctx).Infof("Removing %s from %s", containerPath, container.ID())
+			if err := c.execContainer(ctx, container.ID(), "rm", "-rf", containerPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return c.Copy(ctx, CopyOptions{
+		Source:      hostPath,
+		Destination: fmt.Sprintf("%s:%s", trigger.service, containerPath),
+	})
+}
+
+// watchTargetPath returns the path in the containers that the host path under the trigger path is synced to.
+func watchTargetPath(trigger types.Trigger, hostPath string) (string, error) {
+	rel, err := filepath.Rel(trigger.Path, hostPath)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(trigger.Target, filepath.ToSlash(rel)), nil
+}
+
+// recreateService recreates all the containers of the service, without touching its dependencies.
+// The services joining the namespaces of the service are recreated as well.
+func (c *Composer) recreateService(ctx context.Context, service string) error {
+	svc, err := c.project.GetService(service)
+	if err != nil {
+		return err
+	}
+	ps, err := serviceparser.Parse(c.project, svc)
+	if err != nil {
+		return err
+	}
+	for _, container := range ps.Containers {
+		existingCid, err := c.containerID(ctx, container.Name, ps.Unparsed.Name)
+		if err != nil {
+			return fmt.Errorf("error while checking for containers with name %q: %w", container.Name, err)
+		}
+		if _, err := c.upServiceContainer(ctx, ps, container, existingCid, upContainerOptions{recreate: RecreateForce}); err != nil {
+			return err
+		}
+	}
+	for _, dependent := range c.project.Services {
+		if slices.Contains(serviceparser.NamespaceServices(dependent), service) {
+			if err := c.recreateService(ctx, dependent.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestTriggerMatches(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "src")
+	trigger := types.Trigger{
+		Path:   root,
+		Action: types.WatchActionSync,
+		Target: "/app/src",
+		Ignore: []string{"node_modules/", "*.tmp", "build/out"},
+	}
+
+	testCases := []struct {
+		name     string
+		hostPath string
+		expected bool
+	}{
+		{"the trigger path itself", root, true},
+		{"a file under the trigger path", filepath.Join(root, "index.js"), true},
+		{"a file in a subdirectory", filepath.Join(root, "lib", "util.js"), true},
+		{"a file outside of the trigger path", filepath.Join(filepath.Dir(root), "other", "index.js"), false},
+		{"a sibling directory sharing the prefix", root + "-old", false},
+		{"an ignored directory", filepath.Join(root, "node_modules"), false},
+		{"a file in an ignored directory", filepath.Join(root, "node_modules", "pkg", "index.js"), false},
+		{"an ignored file name", filepath.Join(root, "lib", "cache.tmp"), false},
+		{"an ignored relative path", filepath.Join(root, "build", "out"), false},
+		{"a path sharing an element with an ignored relative path", filepath.Join(root, "build", "main.js"), true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, triggerMatches(trigger, tc.hostPath), tc.expected)
+		})
+	}
+}
+
+func TestWatchTargetPath(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "src")
+	trigger := types.Trigger{
+		Path:   root,
+		Action: types.WatchActionSync,
+		Target: "/app/src",
+	}
+
+	testCases := []struct {
+		hostPath string
+		expected string
+	}{
+		{root, "/app/src"},
+		{filepath.Join(root, "index.js"), "/app/src/index.js"},
+		{filepath.Join(root, "lib", "util.js"), "/app/src/lib/util.js"},
+	}
+	for _, tc := range testCases {
+		target, err := watchTargetPath(trigger, tc.hostPath)
+		assert.NilError(t, err)
+		assert.Equal(t, target, tc.expected)
+	}
+}
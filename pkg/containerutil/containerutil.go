@@ -303,7 +303,10 @@ func Start(ctx context.Context, container containerd.Container, isAttach bool, i
 		return err
 	}
 
-	// If container has health checks configured, create and start systemd timer/service files.
+	// If container has health checks configured, reset its health state and create and start systemd timer/service files.
+	if err := healthcheck.ResetHealthState(ctx, container); err != nil {
+		return fmt.Errorf("failed to reset health state: %w", err)
+	}
 	if err := healthcheck.CreateTimer(ctx, container, cfg, nerdctlCmd, nerdctlArgs); err != nil {
 		return fmt.Errorf("failed to create healthcheck timer: %w", err)
 	}
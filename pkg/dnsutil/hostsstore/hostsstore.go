@@ -90,6 +90,7 @@ type Store interface {
 	Acquire(Meta) error
 	Release(id string) error
 	Update(id, newName string) error
+	UpdateNetwork(id, network string, result *types100.Result) error
 	HostsPath(id string) (location string, err error)
 	Delete(id string) (err error)
 	AllocHostsFile(id string, content []byte) (location string, err error)
@@ -259,6 +260,47 @@ func (x *hostsStore) Update(id, newName string) (err error) {
 	})
 }
 
+// UpdateNetwork records the CNI result of a network connected to a running container.
+// A nil result removes the network from the container.
+func (x *hostsStore) UpdateNetwork(id, network string, result *types100.Result) (err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Join(ErrHostsStore, err)
+		}
+	}()
+
+	return x.safeStore.WithLock(func() error {
+		var content []byte
+		if content, err = x.safeStore.Get(id, metaJSON); err != nil {
+			return err
+		}
+
+		meta := &Meta{}
+		if err = json.Unmarshal(content, meta); err != nil {
+			return err
+		}
+
+		if result == nil {
+			delete(meta.Networks, network)
+		} else {
+			if meta.Networks == nil {
+				meta.Networks = make(map[string]*types100.Result)
+			}
+			meta.Networks[network] = result
+		}
+		content, err = json.Marshal(meta)
+		if err != nil {
+			return err
+		}
+
+		if err = x.safeStore.Set(content, id, metaJSON); err != nil {
+			return err
+		}
+
+		return x.updateAllHosts()
+	})
+}
+
 func (x *hostsStore) updateAllHosts() (err error) {
 	entries, err := x.safeStore.List()
 	if err != nil {
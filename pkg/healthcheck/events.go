@@ -0,0 +1,56 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/typeurl/v2"
+)
+
+// HealthStatusEventTopic is the topic of the health_status events published on the containerd event bus.
+const HealthStatusEventTopic = "/nerdctl/health_status"
+
+// HealthStatusEvent is published when the health status of a container changes.
+type HealthStatusEvent struct {
+	ContainerID string       `json:"container_id"`
+	Status      HealthStatus `json:"status"`
+	// Action is the `--health-on-failure` action taken on the container, if any.
+	Action string `json:"action,omitempty"`
+}
+
+func init() {
+	typeurl.Register(&HealthStatusEvent{}, "nerdctl", "healthcheck", "HealthStatusEvent")
+}
+
+// publishHealthStatusEvent publishes a health_status event for the container.
+// When the container becomes unhealthy, the event also carries the `--health-on-failure` action of the container.
+func publishHealthStatusEvent(ctx context.Context, client *containerd.Client, container containerd.Container, status HealthStatus) error {
+	event := &HealthStatusEvent{
+		ContainerID: container.ID(),
+		Status:      status,
+	}
+	if status == Unhealthy {
+		lbs, err := container.Labels(ctx)
+		if err != nil {
+			return err
+		}
+		event.Action = healthOnFailureAction(lbs)
+	}
+	return client.EventService().Publish(ctx, HealthStatusEventTopic, event)
+}
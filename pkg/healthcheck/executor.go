@@ -33,9 +33,19 @@ import (
 )
 
 // ExecuteHealthCheck executes the health check command for a container
-func ExecuteHealthCheck(ctx context.Context, task containerd.Task, container containerd.Container, hc *Healthcheck) error {
+func ExecuteHealthCheck(ctx context.Context, client *containerd.Client, task containerd.Task, container containerd.Container, hc *Healthcheck) error {
+	// The startup check runs in place of the health check until it succeeds
+	startup, err := pendingStartupCheck(ctx, container, hc)
+	if err != nil {
+		return err
+	}
+	probe := hc
+	if startup != nil {
+		probe = startup
+	}
+
 	// Prepare process spec for health check command
-	processSpec, err := prepareProcessSpec(ctx, container, hc)
+	processSpec, err := prepareProcessSpec(ctx, container, probe)
 	if err != nil {
 		return err
 	}
@@ -44,9 +54,9 @@ func ExecuteHealthCheck(ctx context.Context, task containerd.Task, container con
 	}
 
 	startTime := time.Now()
-	result, err := probeHealthCheck(ctx, task, hc, processSpec)
+	result, err := probeHealthCheck(ctx, task, probe, processSpec)
 	if err != nil {
-		_ = updateHealthStatus(ctx, container, hc, &HealthcheckResult{
+		_ = updateHealthStatus(ctx, client, container, hc, startup != nil, &HealthcheckResult{
 			Start:    startTime,
 			End:      time.Now(),
 			ExitCode: -1,
@@ -57,7 +67,7 @@ func ExecuteHealthCheck(ctx context.Context, task containerd.Task, container con
 
 	// Success case, update health status
 	result.Start = startTime
-	if err := updateHealthStatus(ctx, container, hc, result); err != nil {
+	if err := updateHealthStatus(ctx, client, container, hc, startup != nil, result); err != nil {
 		return fmt.Errorf("failed to update health status: %w", err)
 	}
 	return nil
@@ -117,8 +127,10 @@ func probeHealthCheck(ctx context.Context, task containerd.Task, hc *Healthcheck
 	}
 }
 
-// updateHealthStatus updates the health status based on the health check result
-func updateHealthStatus(ctx context.Context, container containerd.Container, hcConfig *Healthcheck, hcResult *HealthcheckResult) error {
+// updateHealthStatus updates the health status based on the health check (or startup check) result.
+// On a status change, it publishes a health_status event, and applies the `--health-on-failure` action
+// when the container becomes unhealthy.
+func updateHealthStatus(ctx context.Context, client *containerd.Client, container containerd.Container, hcConfig *Healthcheck, startupCheck bool, hcResult *HealthcheckResult) error {
 	// Get current health state from labels
 	currentHealth, err := readHealthStateFromLabels(ctx, container)
 	if err != nil {
@@ -133,19 +145,34 @@ func updateHealthStatus(ctx context.Context, container containerd.Container, hcC
 			InStartPeriod: hasStartPeriod,
 		}
 	}
+	prevStatus := currentHealth.Status
 
 	// Get container info for start period check
 	info, err := container.Info(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get container info: %w", err)
 	}
-	containerCreated := info.CreatedAt
+	containerStarted := startPeriodOrigin(info, currentHealth)
 
 	// Check if we're in start period workflow
-	inStartPeriodTime := hcResult.Start.Sub(containerCreated) < hcConfig.StartPeriod
+	inStartPeriodTime := hcResult.Start.Sub(containerStarted) < hcConfig.StartPeriod
 	inStartPeriodState := currentHealth.InStartPeriod
 
-	if inStartPeriodTime && inStartPeriodState {
+	if startupCheck {
+		// Startup Check Workflow
+		if hcResult.ExitCode == 0 {
+			// The health check takes over once the startup check succeeds
+			currentHealth.StartupPassed = true
+			currentHealth.FailingStreak = 0
+			currentHealth.InStartPeriod = false
+		} else if !inStartPeriodTime {
+			// Failures of the startup check are only ignored during the start period
+			currentHealth.FailingStreak++
+			if currentHealth.FailingStreak >= hcConfig.Retries && currentHealth.Status != Unhealthy {
+				currentHealth.Status = Unhealthy
+			}
+		}
+	} else if inStartPeriodTime && inStartPeriodState {
 		// Start Period Workflow
 		if hcResult.ExitCode == 0 {
 			// First healthy result transitions us out of start period
@@ -178,6 +205,16 @@ func updateHealthStatus(ctx context.Context, container containerd.Container, hcC
 	if err := writeHealthLog(ctx, container, hcResult); err != nil {
 		return fmt.Errorf("failed to write health log: %w", err)
 	}
+
+	if currentHealth.Status == prevStatus {
+		return nil
+	}
+	if err := publishHealthStatusEvent(ctx, client, container, currentHealth.Status); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to publish health_status event for container %s", container.ID())
+	}
+	if currentHealth.Status == Unhealthy {
+		return onUnhealthy(ctx, container, hcConfig)
+	}
 	return nil
 }
 
@@ -18,7 +18,11 @@ package healthcheck
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
 type HealthStatus = string
@@ -39,6 +43,14 @@ const (
 	TestNone = ""
 )
 
+// Actions taken when the container becomes unhealthy (`--health-on-failure`)
+const (
+	OnFailureNone    = "none"
+	OnFailureKill    = "kill"
+	OnFailureRestart = "restart"
+	OnFailureStop    = "stop"
+)
+
 const (
 	DefaultProbeInterval   = 30 * time.Second // Default interval between probe runs. Also applies before the first probe.
 	DefaultProbeTimeout    = 30 * time.Second // Max duration a single probe run may take before it's considered failed.
@@ -74,6 +86,10 @@ type Healthcheck struct {
 	Timeout     time.Duration `json:"Timeout,omitempty"`     // Timeout is the time to wait before considering the check to have hung
 	Retries     int           `json:"Retries,omitempty"`     // Retries is the number of consecutive failures needed to consider a container as unhealthy
 	StartPeriod time.Duration `json:"StartPeriod,omitempty"` // StartPeriod is the period for the container to initialize before the health check starts
+
+	// StartInterval is the time to wait between checks during the start period (Docker API 1.44).
+	// Zero means [Healthcheck.Interval] is used during the start period too.
+	StartInterval time.Duration `json:"StartInterval,omitempty"`
 }
 
 // HealthState stores the current health state of a container
@@ -81,6 +97,8 @@ type HealthState struct {
 	Status        HealthStatus // Status is one of [Starting], [Healthy] or [Unhealthy]
 	FailingStreak int          // FailingStreak is the number of consecutive failures
 	InStartPeriod bool         // InStartPeriod indicates if we're in the start period workflow
+	StartupPassed bool         // StartupPassed indicates the startup check (`--health-startup-cmd`) has succeeded
+	StartedAt     time.Time    // StartedAt is when the container was last started; zero means its creation time
 }
 
 // ToJSONString serializes HealthState to a JSON string for label storage
@@ -110,6 +128,26 @@ func (hc *Healthcheck) ToJSONString() (string, error) {
 	return string(b), nil
 }
 
+// TestFromCommand returns the test of a healthcheck for the command of `--health-cmd` or `--health-startup-cmd`.
+// As in Podman, a JSON array is the exec form of the command (e.g., `["CMD", "curl", "-f", "http://localhost"]`),
+// which does not need a shell in the container. Any other string is run with the shell of the container.
+func TestFromCommand(cmd string) ([]string, error) {
+	var args []string
+	if !strings.HasPrefix(strings.TrimSpace(cmd), "[") || json.Unmarshal([]byte(cmd), &args) != nil {
+		return []string{CmdShell, cmd}, nil
+	}
+	if len(args) > 0 && (args[0] == Cmd || args[0] == CmdShell) {
+		if len(args) < 2 {
+			return nil, fmt.Errorf("healthcheck command %s requires arguments", cmd)
+		}
+		return args, nil
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("healthcheck command %s must not be empty", cmd)
+	}
+	return append([]string{Cmd}, args...), nil
+}
+
 // HealthCheckFromJSON deserializes a JSON string into a Healthcheck struct
 func HealthCheckFromJSON(s string) (*Healthcheck, error) {
 	var hc Healthcheck
@@ -137,6 +175,28 @@ func HealthcheckResultFromJSON(s string) (*HealthcheckResult, error) {
 	return &r, nil
 }
 
+// StatusFromLabels returns the health status recorded in the container labels.
+// It returns [NoHealthcheck] if the container has no healthcheck, and [Starting] if no check has completed yet.
+func StatusFromLabels(containerLabels map[string]string) HealthStatus {
+	hcJSON := containerLabels[labels.HealthCheck]
+	if hcJSON == "" {
+		return NoHealthcheck
+	}
+	hc, err := HealthCheckFromJSON(hcJSON)
+	if err != nil || isHealthCheckDisabled(hc) {
+		return NoHealthcheck
+	}
+	stateJSON := containerLabels[labels.HealthState]
+	if stateJSON == "" {
+		return Starting
+	}
+	state, err := HealthStateFromJSON(stateJSON)
+	if err != nil {
+		return NoHealthcheck
+	}
+	return state.Status
+}
+
 // ApplyDefaults sets default values for unset healthcheck fields
 func (hc *Healthcheck) ApplyDefaults() {
 	if hc.Interval == 0 {
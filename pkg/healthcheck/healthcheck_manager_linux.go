@@ -17,11 +17,17 @@
 package healthcheck
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
@@ -31,18 +37,22 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/config"
 	"github.com/containerd/nerdctl/v2/pkg/defaults"
-	"github.com/containerd/nerdctl/v2/pkg/labels"
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 )
 
+// schedulerPIDFilename is the name of the file in the container state directory that holds
+// the PID of the healthcheck scheduler process.
+const schedulerPIDFilename = "healthcheck.pid"
+
 // CreateTimer sets up the transient systemd timer and service for healthchecks.
+// When systemd cannot be used, the healthchecks are run by a scheduler process instead.
 func CreateTimer(ctx context.Context, container containerd.Container, cfg *config.Config, nerdctlCmd string, nerdctlArgs []string) error {
 	hc := extractHealthcheck(ctx, container)
-	if hc == nil {
+	if isHealthCheckDisabled(hc) {
 		return nil
 	}
 	if shouldSkipHealthCheckSystemd(hc, cfg) {
-		return nil
+		return startScheduler(ctx, container, nerdctlCmd, nerdctlArgs)
 	}
 
 	containerID := container.ID()
@@ -112,16 +122,81 @@ func StartTimer(ctx context.Context, container containerd.Container, cfg *config
 	return nil
 }
 
-// RemoveTransientHealthCheckFiles stops and cleans up the transient timer and service.
+// RemoveTransientHealthCheckFiles stops and cleans up the transient timer and service,
+// or the scheduler process.
 func RemoveTransientHealthCheckFiles(ctx context.Context, container containerd.Container) error {
 	hc := extractHealthcheck(ctx, container)
 	if hc == nil {
 		return nil
 	}
 
+	if err := stopScheduler(ctx, container); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to stop the healthcheck scheduler of container %s", container.ID())
+	}
 	return ForceRemoveTransientHealthCheckFiles(ctx, container.ID())
 }
 
+// startScheduler starts the scheduler process (`nerdctl internal healthcheck-scheduler`) that runs
+// the healthchecks of the container when systemd timers cannot be used.
+// The process is detached so that it keeps running after nerdctl exits, and its PID is recorded
+// in the container state directory for RemoveTransientHealthCheckFiles.
+func startScheduler(ctx context.Context, container containerd.Container, nerdctlCmd string, nerdctlArgs []string) error {
+	pidFile, err := schedulerPIDFile(ctx, container)
+	if err != nil {
+		return err
+	}
+	// a restarted or unpaused container may still have a scheduler running
+	if err := stopScheduler(ctx, container); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to stop the previous healthcheck scheduler of container %s", container.ID())
+	}
+
+	args := append(slices.Clone(nerdctlArgs), "internal", "healthcheck-scheduler", container.ID())
+	log.G(ctx).Debugf("starting healthcheck scheduler with: %s %s", nerdctlCmd, strings.Join(args, " "))
+	cmd := exec.Command(nerdctlCmd, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start healthcheck scheduler: %w", err)
+	}
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0o644); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return fmt.Errorf("failed to write healthcheck scheduler pid file: %w", err)
+	}
+	// reap the process if it exits while nerdctl is still running (e.g., `nerdctl compose up`)
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
+
+// stopScheduler stops the scheduler process of the container, if any.
+func stopScheduler(ctx context.Context, container containerd.Container) error {
+	pidFile, err := schedulerPIDFile(ctx, container)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(pidFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return fmt.Errorf("invalid healthcheck scheduler pid file %s: %w", pidFile, err)
+	}
+	// the process may have exited already, and its PID may have been reused
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err == nil && bytes.Contains(cmdline, []byte("healthcheck-scheduler")) && bytes.Contains(cmdline, []byte(container.ID())) {
+		log.G(ctx).Debugf("stopping healthcheck scheduler (pid %d) of container %s", pid, container.ID())
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return err
+		}
+	}
+	return os.Remove(pidFile)
+}
+
 // ForceRemoveTransientHealthCheckFiles forcefully stops and cleans up the transient timer and service
 // using just the container ID. This function is non-blocking and uses timeouts to prevent hanging
 // on systemd operations. It logs errors as warnings but continues cleanup attempts.
@@ -240,24 +315,6 @@ func ForceRemoveTransientHealthCheckFiles(ctx context.Context, containerID strin
 	return nil
 }
 
-func extractHealthcheck(ctx context.Context, container containerd.Container) *Healthcheck {
-	l, err := container.Labels(ctx)
-	if err != nil {
-		log.G(ctx).WithError(err).Debugf("could not get labels for container %s", container.ID())
-		return nil
-	}
-	hcStr, ok := l[labels.HealthCheck]
-	if !ok || hcStr == "" {
-		return nil
-	}
-	hc, err := HealthCheckFromJSON(hcStr)
-	if err != nil {
-		log.G(ctx).WithError(err).Debugf("invalid healthcheck config on container %s", container.ID())
-		return nil
-	}
-	return hc
-}
-
 // shouldSkipHealthCheckSystemd determines if healthcheck timers should be skipped.
 func shouldSkipHealthCheckSystemd(hc *Healthcheck, cfg *config.Config) bool {
 	// Don't proceed if systemd is unavailable or disabled
@@ -266,8 +323,22 @@ func shouldSkipHealthCheckSystemd(hc *Healthcheck, cfg *config.Config) bool {
 	}
 
 	// Don't proceed if health check is nil, empty or explicitly NONE.
-	if hc == nil || len(hc.Test) == 0 || hc.Test[0] == "NONE" {
+	if isHealthCheckDisabled(hc) {
 		return true
 	}
-	return false
+
+	// The timers run at a fixed interval, so the scheduler process runs the checks that use a start interval.
+	return hc.StartInterval > 0
+}
+
+// schedulerPIDFile returns the path of the file holding the PID of the scheduler process of the container.
+func schedulerPIDFile(ctx context.Context, container containerd.Container) (string, error) {
+	stateDir, err := getContainerStateDir(ctx, container)
+	if err != nil {
+		return "", err
+	}
+	if stateDir == "" {
+		return "", fmt.Errorf("container %s has no state directory", container.ID())
+	}
+	return filepath.Join(stateDir, schedulerPIDFilename), nil
 }
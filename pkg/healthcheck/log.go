@@ -183,6 +183,25 @@ func readHealthStateFromLabels(ctx context.Context, container containerd.Contain
 	return state, nil
 }
 
+// extractHealthcheck returns the health check configuration from the container labels, or nil if there is none.
+func extractHealthcheck(ctx context.Context, container containerd.Container) *Healthcheck {
+	l, err := container.Labels(ctx)
+	if err != nil {
+		log.G(ctx).WithError(err).Debugf("could not get labels for container %s", container.ID())
+		return nil
+	}
+	hcStr, ok := l[labels.HealthCheck]
+	if !ok || hcStr == "" {
+		return nil
+	}
+	hc, err := HealthCheckFromJSON(hcStr)
+	if err != nil {
+		log.G(ctx).WithError(err).Debugf("invalid healthcheck config on container %s", container.ID())
+		return nil
+	}
+	return hc
+}
+
 // getContainerStateDir returns the container's state directory from labels.
 func getContainerStateDir(ctx context.Context, container containerd.Container) (string, error) {
 	info, err := container.Info(ctx)
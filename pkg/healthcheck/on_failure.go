@@ -0,0 +1,201 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/runtime/restart"
+	"github.com/containerd/containerd/v2/pkg/cio"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// defaultStopTimeout is the time to wait for the container to exit after the stop signal,
+// when the container has no stop timeout configured.
+const defaultStopTimeout = 10 * time.Second
+
+// onUnhealthy is called when the health status of the container flips to unhealthy.
+// It applies the `--health-on-failure` action of the container.
+func onUnhealthy(ctx context.Context, container containerd.Container, hcConfig *Healthcheck) error {
+	lbs, err := container.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get container labels: %w", err)
+	}
+
+	switch action := healthOnFailureAction(lbs); action {
+	case OnFailureNone:
+		return nil
+	case OnFailureKill:
+		log.G(ctx).Infof("container %s is unhealthy, killing it", container.ID())
+		if err := markStopped(ctx, container, lbs); err != nil {
+			return err
+		}
+		return stopTask(ctx, container, 0)
+	case OnFailureStop:
+		log.G(ctx).Infof("container %s is unhealthy, stopping it", container.ID())
+		if err := markStopped(ctx, container, lbs); err != nil {
+			return err
+		}
+		timeout := defaultStopTimeout
+		if t, ok := lbs[labels.StopTimeout]; ok {
+			if timeout, err = time.ParseDuration(t + "s"); err != nil {
+				return err
+			}
+		}
+		return stopTask(ctx, container, timeout)
+	case OnFailureRestart:
+		log.G(ctx).Infof("container %s is unhealthy, restarting it", container.ID())
+		if err := restartTask(ctx, container, lbs); err != nil {
+			return err
+		}
+		// the restarted container goes through the start period again
+		return writeHealthStateToLabels(ctx, container, newStartingHealthState(hcConfig))
+	default:
+		return fmt.Errorf("unknown health-on-failure action %q", action)
+	}
+}
+
+// healthOnFailureAction returns the `--health-on-failure` action recorded in the container labels.
+func healthOnFailureAction(containerLabels map[string]string) string {
+	if action := containerLabels[labels.HealthOnFailure]; action != "" {
+		return action
+	}
+	return OnFailureNone
+}
+
+// restartTask restarts the task of the container and increments its restart count.
+//
+// When the container has an active restart policy, the task is killed and the restart is left
+// to the restart monitor of containerd, which increments the restart count on its own.
+func restartTask(ctx context.Context, container containerd.Container, lbs map[string]string) error {
+	killed := containerd.Status{Status: containerd.Stopped, ExitStatus: uint32(128 + syscall.SIGKILL)}
+	if lbs[restart.StatusLabel] == string(containerd.Running) && restart.Reconcile(killed, lbs) {
+		return stopTask(ctx, container, 0)
+	}
+
+	if err := stopTask(ctx, container, 0); err != nil {
+		return err
+	}
+	if task, err := container.Task(ctx, nil); err == nil {
+		if _, err := task.Delete(ctx); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	count, _ := strconv.Atoi(lbs[restart.CountLabel])
+	opt := containerd.WithAdditionalContainerLabels(map[string]string{
+		restart.CountLabel: strconv.Itoa(count + 1),
+	})
+	if err := container.Update(ctx, containerd.UpdateContainerOpts(opt)); err != nil {
+		return err
+	}
+
+	// Same as the restart monitor of containerd, the new task writes its output to the log URI of the container.
+	ioCreator := cio.NullIO
+	if logURI := lbs[labels.LogURI]; logURI != "" {
+		spec, err := container.Spec(ctx)
+		if err != nil {
+			return err
+		}
+		uri, err := url.Parse(logURI)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v into url: %w", logURI, err)
+		}
+		if spec.Process != nil && spec.Process.Terminal {
+			ioCreator = cio.TerminalLogURI(uri)
+		} else {
+			ioCreator = cio.LogURI(uri)
+		}
+	}
+	task, err := container.NewTask(ctx, ioCreator)
+	if err != nil {
+		return err
+	}
+	return task.Start(ctx)
+}
+
+// stopTask sends the stop signal of the container to its task, and SIGKILL if the task has not
+// exited within timeout. A zero timeout sends SIGKILL right away.
+func stopTask(ctx context.Context, container containerd.Container, timeout time.Duration) error {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Status == containerd.Stopped {
+		return nil
+	}
+	exitCh, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	if timeout > 0 {
+		sig, err := containerd.GetStopSignal(ctx, container, syscall.SIGTERM)
+		if err != nil {
+			return err
+		}
+		if err := task.Kill(ctx, sig); err != nil {
+			return err
+		}
+		select {
+		case <-exitCh:
+			return nil
+		case <-time.After(timeout):
+		}
+	}
+
+	if err := task.Kill(ctx, syscall.SIGKILL, containerd.WithKillAll); err != nil {
+		return err
+	}
+	select {
+	case <-exitCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// markStopped prevents the restart policy of the container from restarting it, the same as `nerdctl stop`
+// does for the "unless-stopped" policy. The desired status of the restart monitor of containerd is set to
+// stopped too, as the "always" and "on-failure" policies would otherwise restart the container.
+// `nerdctl start` sets it back to running.
+func markStopped(ctx context.Context, container containerd.Container, lbs map[string]string) error {
+	newLabels := map[string]string{
+		restart.ExplicitlyStoppedLabel: strconv.FormatBool(true),
+	}
+	if _, ok := lbs[restart.PolicyLabel]; ok {
+		newLabels[restart.StatusLabel] = string(containerd.Stopped)
+	}
+	opt := containerd.WithAdditionalContainerLabels(newLabels)
+	return container.Update(ctx, containerd.UpdateContainerOpts(opt))
+}
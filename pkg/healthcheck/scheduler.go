@@ -0,0 +1,75 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+)
+
+// RunScheduler executes the health check of the container every interval, until the container
+// is removed or ctx is cancelled.
+// The scheduler process is killed when the container is stopped or killed with nerdctl
+// (see RemoveTransientHealthCheckFiles), and started again when the container is started.
+// It is the main loop of the scheduler process that runs the health checks when systemd timers
+// cannot be used (e.g., systemd is not available, `disable_hc_systemd` is set, or in rootless mode).
+func RunScheduler(ctx context.Context, client *containerd.Client, containerID string) error {
+	for {
+		container, err := client.LoadContainer(ctx, containerID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		hc := extractHealthcheck(ctx, container)
+		if isHealthCheckDisabled(hc) {
+			return nil
+		}
+		hc.ApplyDefaults()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(nextCheckInterval(ctx, container, hc)):
+		}
+
+		// the task may not be running when the container exited by itself or is being restarted,
+		// so the health check is skipped until the task is running again.
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			log.G(ctx).WithError(err).Debugf("skipping health check of container %s", containerID)
+			continue
+		}
+		status, err := task.Status(ctx)
+		if err != nil || status.Status != containerd.Running {
+			continue
+		}
+		if err := ExecuteHealthCheck(ctx, client, task, container, hc); err != nil {
+			log.G(ctx).WithError(err).Debugf("health check of container %s failed", containerID)
+		}
+	}
+}
+
+// isHealthCheckDisabled returns true if the health check is nil, empty or explicitly NONE.
+func isHealthCheckDisabled(hc *Healthcheck) bool {
+	return hc == nil || len(hc.Test) == 0 || hc.Test[0] == CmdNone
+}
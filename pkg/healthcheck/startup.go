@@ -0,0 +1,115 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/containers"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+// pendingStartupCheck returns the startup check of the container (`--health-startup-cmd`) if it has not
+// succeeded yet, or nil otherwise.
+// The startup check shares the configuration of the health check, except for its command.
+func pendingStartupCheck(ctx context.Context, container containerd.Container, hc *Healthcheck) (*Healthcheck, error) {
+	lbs, err := container.Labels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container labels: %w", err)
+	}
+	startupJSON := lbs[labels.HealthStartupCheck]
+	if startupJSON == "" {
+		return nil, nil
+	}
+	if stateJSON := lbs[labels.HealthState]; stateJSON != "" {
+		state, err := HealthStateFromJSON(stateJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse health state: %w", err)
+		}
+		if state.StartupPassed {
+			return nil, nil
+		}
+	}
+
+	var test []string
+	if err := json.Unmarshal([]byte(startupJSON), &test); err != nil {
+		return nil, fmt.Errorf("failed to parse startup check: %w", err)
+	}
+	startup := *hc
+	startup.Test = test
+	return &startup, nil
+}
+
+// nextCheckInterval returns the time to wait before the next check of the container:
+// StartInterval while the container is starting up, Interval otherwise.
+func nextCheckInterval(ctx context.Context, container containerd.Container, hc *Healthcheck) time.Duration {
+	if hc.StartInterval <= 0 {
+		return hc.Interval
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return hc.Interval
+	}
+	state := &HealthState{Status: Starting, InStartPeriod: hc.StartPeriod > 0}
+	if stateJSON := info.Labels[labels.HealthState]; stateJSON != "" {
+		parsed, err := HealthStateFromJSON(stateJSON)
+		if err != nil {
+			return hc.Interval
+		}
+		state = parsed
+	}
+
+	if info.Labels[labels.HealthStartupCheck] != "" && !state.StartupPassed {
+		return hc.StartInterval
+	}
+	if state.InStartPeriod && time.Since(startPeriodOrigin(info, state)) < hc.StartPeriod {
+		return hc.StartInterval
+	}
+	return hc.Interval
+}
+
+// startPeriodOrigin returns the time the start period of the container is counted from.
+func startPeriodOrigin(info containers.Container, state *HealthState) time.Time {
+	if !state.StartedAt.IsZero() {
+		return state.StartedAt
+	}
+	return info.CreatedAt
+}
+
+// ResetHealthState resets the health state of the container when a new task of the container is started,
+// so that the restarted container goes through the start period and the startup check again.
+func ResetHealthState(ctx context.Context, container containerd.Container) error {
+	hc := extractHealthcheck(ctx, container)
+	if isHealthCheckDisabled(hc) {
+		return nil
+	}
+	return writeHealthStateToLabels(ctx, container, newStartingHealthState(hc))
+}
+
+// newStartingHealthState returns the health state of a container that has just been started.
+func newStartingHealthState(hc *Healthcheck) *HealthState {
+	return &HealthState{
+		Status:        Starting,
+		InStartPeriod: hc.StartPeriod > 0,
+		StartedAt:     time.Now(),
+	}
+}
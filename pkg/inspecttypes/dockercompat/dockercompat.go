@@ -322,7 +322,8 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 		Platform: runtime.GOOS, // for Docker compatibility, this Platform string does NOT contain arch like "/amd64"
 	}
 	c.HostConfig = new(HostConfig)
-	if n.Labels[restart.StatusLabel] == string(containerd.Running) {
+	// containers without a restart policy can still be restarted by `--health-on-failure=restart`
+	if n.Labels[restart.StatusLabel] == string(containerd.Running) || n.Labels[restart.PolicyLabel] == "" {
 		c.RestartCount, _ = strconv.Atoi(n.Labels[restart.CountLabel])
 	}
 	containerAnnotations := make(map[string]string)
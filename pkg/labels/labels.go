@@ -44,6 +44,9 @@ const (
 	// ComposeConfigHash stores the service configuration hash used for convergence decisions
 	ComposeConfigHash = "com.docker.compose.config-hash"
 
+	// ComposeConfigFiles stores the comma-separated paths of the compose files of the project
+	ComposeConfigFiles = "com.docker.compose.project.config_files"
+
 	// Hostname
 	Hostname = Prefix + "hostname"
 
@@ -128,4 +131,10 @@ const (
 
 	// HealthState stores the current health state (status and failing streak).
 	HealthState = Prefix + "healthstate"
+
+	// HealthOnFailure stores the action to take when the container becomes unhealthy (`--health-on-failure`).
+	HealthOnFailure = Prefix + "health-on-failure"
+
+	// HealthStartupCheck stores the startup check command run in place of the health check until it succeeds (`--health-startup-cmd`).
+	HealthStartupCheck = Prefix + "health-startup-check"
 )
@@ -30,6 +30,7 @@ import (
 	"strconv"
 
 	"github.com/containernetworking/cni/libcni"
+	types100 "github.com/containernetworking/cni/pkg/types/100"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/pkg/namespaces"
@@ -331,6 +332,38 @@ func (e *CNIEnv) RemoveNetwork(net *NetworkConfig) error {
 	return fsRemove(e, net)
 }
 
+// AttachNetwork invokes the CNI plugins of netw to attach the container identified by fullID
+// (i.e., "<namespace>-<container ID>") to the network namespace at nsPath.
+// The interface created in the network namespace is named ifName.
+func (e *CNIEnv) AttachNetwork(ctx context.Context, netw *NetworkConfig, fullID, nsPath, ifName string, args [][2]string) (*types100.Result, error) {
+	rt := &libcni.RuntimeConf{
+		ContainerID: fullID,
+		NetNS:       nsPath,
+		IfName:      ifName,
+		Args:        args,
+	}
+	res, err := e.cniConfig().AddNetworkList(ctx, netw.NetworkConfigList, rt)
+	if err != nil {
+		return nil, err
+	}
+	return types100.NewResultFromResult(res)
+}
+
+// DetachNetwork invokes the CNI plugins of netw to detach the interface ifName of the container
+// identified by fullID. nsPath may be empty when the network namespace does not exist anymore.
+func (e *CNIEnv) DetachNetwork(ctx context.Context, netw *NetworkConfig, fullID, nsPath, ifName string) error {
+	rt := &libcni.RuntimeConf{
+		ContainerID: fullID,
+		NetNS:       nsPath,
+		IfName:      ifName,
+	}
+	return e.cniConfig().DelNetworkList(ctx, netw.NetworkConfigList, rt)
+}
+
+func (e *CNIEnv) cniConfig() *libcni.CNIConfig {
+	return libcni.NewCNIConfig([]string{e.Path}, nil)
+}
+
 // GetDefaultNetworkConfig checks whether the default network exists
 // by first searching for if any network bears the `labels.NerdctlDefaultNetwork`
 // label, or falls back to checking whether any network bears the
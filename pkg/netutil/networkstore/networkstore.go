@@ -57,6 +57,9 @@ func New(dataStore, namespace, containerID string) (ns *NetworkStore, err error)
 
 type NetworkConfig struct {
 	PortMappings []cni.PortMapping `json:"portMappings,omitempty"`
+	// Interfaces maps the networks connected to the running task with `nerdctl network connect`
+	// to the name of their interface in the network namespace of the task.
+	Interfaces map[string]string `json:"interfaces,omitempty"`
 }
 
 type NetworkStore struct {
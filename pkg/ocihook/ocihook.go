@@ -45,6 +45,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/namestore"
 	"github.com/containerd/nerdctl/v2/pkg/netutil"
 	"github.com/containerd/nerdctl/v2/pkg/netutil/nettype"
+	"github.com/containerd/nerdctl/v2/pkg/netutil/networkstore"
 	"github.com/containerd/nerdctl/v2/pkg/ocihook/state"
 	"github.com/containerd/nerdctl/v2/pkg/portutil"
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
@@ -193,6 +194,7 @@ func newHandlerOpts(state *specs.State, dataStore, cniPath, cniNetconfPath, brid
 			cniOpts = append(cniOpts, cni.WithConfListBytes(netw.Bytes))
 			o.cniNames = append(o.cniNames, netstr)
 		}
+		o.cniEnv = e
 		o.cni, err = cni.New(cniOpts...)
 		if err != nil {
 			return nil, err
@@ -257,6 +259,7 @@ type handlerOpts struct {
 	rootfs            string
 	ports             []cni.PortMapping
 	cni               cni.CNI
+	cniEnv            *netutil.CNIEnv
 	cniNames          []string
 	fullID            string
 	rootlessKitClient rlkclient.Client
@@ -670,6 +673,12 @@ func onPostStop(opts *handlerOpts) error {
 	ctx := context.Background()
 	ns := opts.state.Annotations[labels.Namespace]
 	if opts.cni != nil {
+		// Remove the networks connected with `nerdctl network connect` after the task was started first,
+		// so that they are removed even when the removal of the other networks fails
+		if err := removeConnectedNetworks(ctx, opts); err != nil {
+			log.L.WithError(err).Warnf("failed to remove the networks connected to container %s", opts.fullID)
+		}
+
 		var err error
 		b4nnEnabled, b4nnBindEnabled, err := bypass4netnsutil.IsBypass4netnsEnabled(opts.state.Annotations)
 		if err != nil {
@@ -751,6 +760,33 @@ func onPostStop(opts *handlerOpts) error {
 	return nil
 }
 
+// removeConnectedNetworks invokes the CNI plugins of the networks connected to the task
+// with `nerdctl network connect`, which are not part of opts.cni.
+func removeConnectedNetworks(ctx context.Context, opts *handlerOpts) error {
+	ns, err := networkstore.New(opts.dataStore, opts.state.Annotations[labels.Namespace], opts.state.ID)
+	if err != nil {
+		return err
+	}
+	if err := ns.Load(); err != nil {
+		return err
+	}
+	if len(ns.NetConf.Interfaces) == 0 {
+		return nil
+	}
+	for name, ifName := range ns.NetConf.Interfaces {
+		netw, err := opts.cniEnv.NetworkByNameOrID(name)
+		if err != nil {
+			log.L.WithError(err).Warnf("failed to remove network %s from container %s", name, opts.fullID)
+			continue
+		}
+		if err := opts.cniEnv.DetachNetwork(ctx, netw, opts.fullID, "", ifName); err != nil {
+			log.L.WithError(err).Warnf("failed to remove network %s from container %s", name, opts.fullID)
+		}
+	}
+	ns.NetConf.Interfaces = nil
+	return ns.Acquire(ns.NetConf)
+}
+
 // cleanupIptablesRules cleans up iptables rules related to the container
 func cleanupIptablesRules(containerID string) error {
 	// Check if iptables command exists
@@ -35,6 +35,7 @@ type SystemInfo struct {
 type StatsEntry struct {
 	Name             string
 	ID               string
+	Service          string // the compose service, if any
 	CPUPercentage    float64
 	Memory           float64
 	MemoryLimit      float64
@@ -51,6 +52,7 @@ type StatsEntry struct {
 type FormattedStatsEntry struct {
 	Name     string
 	ID       string
+	Service  string
 	CPUPerc  string
 	MemUsage string
 	MemPerc  string
@@ -82,12 +84,14 @@ func NewStats(containerID string, containerName string) *Stats {
 func (cs *Stats) SetStatistics(s StatsEntry) {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
-	// The statsEntry ID and Name fields are already populated within the cs.StatsEntry
+	// The statsEntry ID, Name and Service fields are already populated within the cs.StatsEntry
 	cStatsName := cs.StatsEntry.Name
 	cStatsID := cs.StatsEntry.ID
+	cStatsService := cs.StatsEntry.Service
 	cs.StatsEntry = s
 	cs.StatsEntry.Name = cStatsName
 	cs.StatsEntry.ID = cStatsID
+	cs.StatsEntry.Service = cStatsService
 }
 
 // GetStatistics is from https://github.com/docker/cli/blob/3fb4fb83dfb5db0c0753a8316f21aea54dab32c5/cli/command/container/formatter_stats.go#L95-L100
@@ -136,6 +140,7 @@ func RenderEntry(in *StatsEntry, noTrunc bool) FormattedStatsEntry {
 	return FormattedStatsEntry{
 		Name:     in.EntryName(noTrunc),
 		ID:       in.EntryID(noTrunc),
+		Service:  in.Service,
 		CPUPerc:  in.CPUPerc(),
 		MemUsage: in.MemUsage(),
 		MemPerc:  in.MemPerc(),